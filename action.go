@@ -38,6 +38,9 @@ type ActionData struct {
 
 	CheckItem *CheckItem `json:"checkItem"`
 	Checklist *Checklist `json:"checklist"`
+
+	Label        *Label        `json:"label,omitempty"`
+	Organization *Organization `json:"organization,omitempty"`
 }
 
 // ActionDataCard represent the nested 'card' data attribute of actions
@@ -83,6 +86,17 @@ func (c *Card) GetActions(extraArgs ...Arguments) (actions ActionCollection, err
 	return
 }
 
+// GetActions makes a GET for the actions a member has performed
+func (m *Member) GetActions(extraArgs ...Arguments) (actions ActionCollection, err error) {
+	args := flattenArguments(extraArgs)
+	path := fmt.Sprintf("members/%s/actions", m.ID)
+	err = m.client.Get(path, args, &actions)
+	for _, action := range actions {
+		action.SetClient(m.client)
+	}
+	return
+}
+
 // GetListChangeActions retrieves a slice of Actions which resulted in changes
 // to the card's active List. This includes the createCard and copyCard action (which
 // place the card in its first list), and the updateCard:closed action (which remove it
@@ -5,6 +5,16 @@
 
 package trello
 
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
 // Attachment represent the attachments of cards. This is a nested resource of Card.
 // https://developers.trello.com/reference/#attachments
 type Attachment struct {
@@ -13,8 +23,8 @@ type Attachment struct {
 	Card      *Card               `json:"-"`
 	Name      string              `json:"name"`
 	Pos       float32             `json:"pos"`
-	Bytes     int                 `json:"int"`
-	Date      string              `json:"date"`
+	Bytes     int                 `json:"bytes"`
+	Date      time.Time           `json:"date"`
 	EdgeColor string              `json:"edgeColor"`
 	IDMember  string              `json:"idMember"`
 	IsUpload  bool                `json:"isUpload"`
@@ -38,3 +48,85 @@ type AttachmentPreview struct {
 func (a *Attachment) SetClient(newClient *Client) {
 	a.client = newClient
 }
+
+// Delete removes the attachment from its card.
+func (a *Attachment) Delete(extraArgs ...Arguments) error {
+	if a.Card == nil {
+		return fmt.Errorf("Attachment %s can't be deleted without its Card", a.ID)
+	}
+	var response interface{}
+	args := flattenArguments(extraArgs)
+	path := fmt.Sprintf("cards/%s/attachments/%s", a.Card.ID, a.ID)
+	err := a.client.Delete(path, args, &response)
+	if err != nil {
+		err = fmt.Errorf("Error deleting attachment %s from card %s: %w", a.ID, a.Card.ID, err)
+	}
+	return err
+}
+
+// Download streams the contents of the attachment to w. Files uploaded to
+// Trello can only be downloaded with the client's credentials, which are
+// sent in the Authorization header. Credentials are never sent to hosts
+// other than Trello's own, so link attachments are fetched anonymously.
+//
+// The contents are streamed rather than read into memory, so the download
+// doesn't pass through the client's Middleware; see Client.Use. It's still
+// logged, with the attachment's URL as the path.
+func (a *Attachment) Download(ctx context.Context, w io.Writer) error {
+	c := a.client
+	if c == nil {
+		return fmt.Errorf("attachment %s has no client; get it from Card.GetAttachment", a.ID)
+	}
+	if err := c.wait(ctx); err != nil {
+		return err
+	}
+
+	r := &Request{Context: ctx, Method: "GET", Path: a.URL, Attempt: 1, token: c.Token}
+	if c.Logger != nil {
+		c.Logger.Debugf("%s", c.redact("[trello] GET "+a.URL))
+	}
+	start := time.Now()
+	resp, err := a.download(r, w)
+	c.logRequest(r, resp, err, time.Since(start))
+	return err
+}
+
+// download sends r and streams the response body to w. The Response it
+// returns has no Body.
+func (a *Attachment) download(r *Request, w io.Writer) (*Response, error) {
+	c := a.client
+	req, err := http.NewRequestWithContext(r.Context, r.Method, a.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid GET request %s: %w", a.URL, err)
+	}
+	if c.isTrelloURL(req.URL) {
+		c.authorize(req)
+	}
+
+	httpResp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP request failure on %s: %w", a.URL, err)
+	}
+	defer httpResp.Body.Close()
+	resp := &Response{StatusCode: httpResp.StatusCode, Header: httpResp.Header}
+	if httpResp.StatusCode < 200 || httpResp.StatusCode > 299 {
+		return resp, makeHTTPClientError(a.URL, httpResp, c.Token)
+	}
+
+	_, err = io.Copy(w, httpResp.Body)
+	if err != nil {
+		return resp, fmt.Errorf("HTTP Read error on response for %s: %w", a.URL, err)
+	}
+	return resp, nil
+}
+
+// isTrelloURL returns true when u points at Trello itself, or at the host
+// the client has been configured to send API requests to.
+func (c *Client) isTrelloURL(u *url.URL) bool {
+	host := u.Hostname()
+	if host == "trello.com" || strings.HasSuffix(host, ".trello.com") {
+		return true
+	}
+	base, err := url.Parse(c.BaseURL)
+	return err == nil && base.Host == u.Host
+}
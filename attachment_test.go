@@ -1,6 +1,15 @@
 package trello
 
-import "testing"
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
 
 func TestAttachmentSetClient(t *testing.T) {
 	a := Attachment{}
@@ -10,3 +19,169 @@ func TestAttachmentSetClient(t *testing.T) {
 		t.Error("Expected non-nil Attachment.client")
 	}
 }
+
+func TestCardGetAttachment(t *testing.T) {
+	card := testCard(t)
+	server := NewMockResponder(t, "cards", "url-attachments.json")
+	server.AssertRequest(func(t *testing.T, r *http.Request) {
+		if r.URL.Path != "/cards/4eea503d91e31d174600008f/attachments/5bbce18fa4a337483b145a57" {
+			t.Errorf("Unexpected path '%s'.", r.URL.Path)
+		}
+	})
+	defer server.Close()
+	card.client.BaseURL = server.URL()
+
+	attachment, err := card.GetAttachment("5bbce18fa4a337483b145a57")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attachment.Bytes != 6654 {
+		t.Errorf("Expected 6654 bytes. Got %d.", attachment.Bytes)
+	}
+	if attachment.Date.IsZero() {
+		t.Error("Expected a parsed attachment Date.")
+	}
+	if attachment.Card != card || attachment.client == nil {
+		t.Error("Expected attachment to reference its card and client.")
+	}
+}
+
+func TestAttachmentDelete(t *testing.T) {
+	card := testCard(t)
+	server := NewMockResponder(t, "cards", "card-attachment-deleted.json")
+	server.AssertRequest(func(t *testing.T, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/cards/4eea503d91e31d174600008f/attachments/5bbce18fa4a337483b145a57" {
+			t.Errorf("Unexpected request %s %s.", r.Method, r.URL.Path)
+		}
+	})
+	defer server.Close()
+	card.client.BaseURL = server.URL()
+
+	attachment := &Attachment{ID: "5bbce18fa4a337483b145a57", Card: card}
+	attachment.SetClient(card.client)
+	if err := attachment.Delete(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAttachmentDeleteWithoutCard(t *testing.T) {
+	attachment := &Attachment{ID: "5bbce18fa4a337483b145a57"}
+	attachment.SetClient(testClient())
+	if err := attachment.Delete(); err == nil {
+		t.Error("Delete() should fail on an attachment without a Card.")
+	}
+}
+
+func TestCardSetCoverAttachment(t *testing.T) {
+	card := testCard(t)
+	server := NewMockResponder(t, "cards", "card-api-example.json")
+	server.AssertRequest(func(t *testing.T, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Query().Get("idAttachmentCover") != "5bbce18fa4a337483b145a57" {
+			t.Errorf("Unexpected request %s %s.", r.Method, r.URL)
+		}
+	})
+	defer server.Close()
+	card.client.BaseURL = server.URL()
+
+	if err := card.SetCoverAttachment("5bbce18fa4a337483b145a57"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAttachmentDownloadSendsCredentialsToTrello(t *testing.T) {
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		rw.Write([]byte("file contents"))
+	}))
+	defer server.Close()
+
+	client := testClient()
+	client.BaseURL = server.URL
+	attachment := &Attachment{URL: server.URL + "/download/report.pdf", IsUpload: true}
+	attachment.SetClient(client)
+
+	var buf bytes.Buffer
+	if err := attachment.Download(context.Background(), &buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "file contents" {
+		t.Errorf("Unexpected download contents '%s'.", buf.String())
+	}
+	if authorization != `OAuth oauth_consumer_key="user", oauth_token="pass"` {
+		t.Errorf("Unexpected Authorization header '%s'.", authorization)
+	}
+}
+
+func TestAttachmentDownloadIsLogged(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Write([]byte("file contents"))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := &recordingLogger{}
+	client := testClient()
+	client.BaseURL = server.URL
+	client.Logger = logger
+	client.StructuredLogger = slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	attachment := &Attachment{URL: server.URL + "/download/report.pdf", IsUpload: true}
+	attachment.SetClient(client)
+
+	if err := attachment.Download(context.Background(), io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	var record map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatal(err)
+	}
+	if record["msg"] != "trello request" || record["method"] != "GET" || record["path"] != attachment.URL || record["status"] != float64(200) {
+		t.Errorf("Unexpected record for the download: %v", record)
+	}
+	if len(logger.lines) != 1 || logger.lines[0] != "[trello] GET "+attachment.URL {
+		t.Errorf("Unexpected log lines %q.", logger.lines)
+	}
+}
+
+func TestAttachmentDownloadDoesNotLeakCredentials(t *testing.T) {
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		rw.Write([]byte("linked page"))
+	}))
+	defer server.Close()
+
+	client := testClient()
+	client.BaseURL = "https://api.trello.com/1"
+	attachment := &Attachment{URL: server.URL + "/page"}
+	attachment.SetClient(client)
+
+	var buf bytes.Buffer
+	if err := attachment.Download(context.Background(), &buf); err != nil {
+		t.Fatal(err)
+	}
+	if authorization != "" {
+		t.Errorf("Credentials should not be sent to third-party hosts. Got '%s'.", authorization)
+	}
+}
+
+func TestAttachmentDownloadWithoutClient(t *testing.T) {
+	attachment := &Attachment{ID: "abc", URL: "https://trello.com/1/cards/def/attachments/abc/download/report.pdf"}
+	if err := attachment.Download(context.Background(), io.Discard); err == nil {
+		t.Error("Expected an error downloading an attachment without a client.")
+	}
+}
+
+func TestAttachmentDownloadError(t *testing.T) {
+	server := mockErrorResponse(404)
+	defer server.Close()
+
+	client := testClient()
+	attachment := &Attachment{URL: server.URL + "/missing"}
+	attachment.SetClient(client)
+
+	err := attachment.Download(context.Background(), &bytes.Buffer{})
+	if !IsNotFound(err) {
+		t.Errorf("Expected a not-found error. Got %v.", err)
+	}
+}
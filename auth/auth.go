@@ -0,0 +1,225 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+// Package auth obtains Trello user tokens through the OAuth 1.0a
+// three-legged authorization flow, so tools don't have to ask users to
+// paste a token by hand.
+//
+//	cfg := &auth.Config{Key: appKey, Secret: appSecret, Name: "Release Bot", Scope: []string{auth.ScopeRead, auth.ScopeWrite}}
+//	client, err := cfg.Authorize(ctx, func(authorizeURL string) error {
+//		fmt.Println("Visit this URL to grant access:", authorizeURL)
+//		return nil
+//	})
+//
+// https://developer.atlassian.com/cloud/trello/guides/rest-api/authorization/
+package auth
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/adlio/trello"
+)
+
+// Default Trello OAuth 1.0a endpoints.
+const (
+	DefaultRequestTokenURL = "https://trello.com/1/OAuthGetRequestToken"
+	DefaultAuthorizeURL    = "https://trello.com/1/OAuthAuthorizeToken"
+	DefaultAccessTokenURL  = "https://trello.com/1/OAuthGetAccessToken"
+)
+
+// Scopes which can be requested for a token.
+const (
+	ScopeRead    = "read"
+	ScopeWrite   = "write"
+	ScopeAccount = "account"
+)
+
+// Lifetimes which can be requested for a token.
+const (
+	Expiration1Hour  = "1hour"
+	Expiration1Day   = "1day"
+	Expiration30Days = "30days"
+	ExpirationNever  = "never"
+)
+
+// Config describes the application requesting access and the token it wants.
+type Config struct {
+	// Key and Secret are the application's API key and OAuth secret from
+	// https://trello.com/power-ups/admin.
+	Key    string
+	Secret string
+
+	// Name is shown to the user on the authorization page.
+	Name string
+
+	// Scope lists the requested permissions. It defaults to ScopeRead.
+	Scope []string
+
+	// Expiration is the requested token lifetime. It defaults to
+	// Expiration30Days.
+	Expiration string
+
+	// CallbackAddr is the address the local callback server listens on
+	// during Authorize. It defaults to 127.0.0.1 on a random port.
+	CallbackAddr string
+
+	// RequestTokenURL, AuthorizeURL and AccessTokenURL override the Trello
+	// endpoints, e.g. to test against a stand-in server.
+	RequestTokenURL string
+	AuthorizeURL    string
+	AccessTokenURL  string
+
+	// HTTPClient is used for the token requests. It defaults to
+	// http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// RequestToken is the temporary credential issued at the start of the flow,
+// before the user has granted access.
+type RequestToken struct {
+	Token  string
+	Secret string
+}
+
+// AccessToken is the credential issued once the user has granted access.
+// Token is the value to use as the token of a trello.Client.
+type AccessToken struct {
+	Token  string
+	Secret string
+}
+
+// GetRequestToken starts the flow by obtaining a RequestToken. Trello will
+// redirect the user to callbackURL once they've granted access. Use "oob"
+// when there's no callback, in which case Trello shows the user a verifier
+// to copy back into the application.
+func (c *Config) GetRequestToken(ctx context.Context, callbackURL string) (*RequestToken, error) {
+	values, err := c.tokenRequest(ctx, c.requestTokenURL(), map[string]string{"oauth_callback": callbackURL}, "")
+	if err != nil {
+		return nil, fmt.Errorf("request token: %w", err)
+	}
+	return &RequestToken{
+		Token:  values.Get("oauth_token"),
+		Secret: values.Get("oauth_token_secret"),
+	}, nil
+}
+
+// AuthorizationURL returns the URL the user must visit to grant access to
+// the RequestToken. It carries the Config's Name, Scope and Expiration.
+func (c *Config) AuthorizationURL(rt *RequestToken) string {
+	scope := c.Scope
+	if len(scope) == 0 {
+		scope = []string{ScopeRead}
+	}
+	expiration := c.Expiration
+	if expiration == "" {
+		expiration = Expiration30Days
+	}
+	params := url.Values{}
+	params.Set("oauth_token", rt.Token)
+	params.Set("scope", strings.Join(scope, ","))
+	params.Set("expiration", expiration)
+	if c.Name != "" {
+		params.Set("name", c.Name)
+	}
+	return c.authorizeURL() + "?" + params.Encode()
+}
+
+// Exchange trades a RequestToken and the verifier Trello handed to the
+// callback for an AccessToken.
+func (c *Config) Exchange(ctx context.Context, rt *RequestToken, verifier string) (*AccessToken, error) {
+	extra := map[string]string{
+		"oauth_token":    rt.Token,
+		"oauth_verifier": verifier,
+	}
+	values, err := c.tokenRequest(ctx, c.accessTokenURL(), extra, rt.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("access token: %w", err)
+	}
+	return &AccessToken{
+		Token:  values.Get("oauth_token"),
+		Secret: values.Get("oauth_token_secret"),
+	}, nil
+}
+
+// Client returns a trello.Client which authenticates with the AccessToken.
+func (c *Config) Client(at *AccessToken) *trello.Client {
+	return trello.NewClient(c.Key, at.Token)
+}
+
+func (c *Config) tokenRequest(ctx context.Context, endpoint string, extra map[string]string, tokenSecret string) (url.Values, error) {
+	authorization, err := c.newSigner().authorization("POST", endpoint, extra, tokenSecret)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", authorization)
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("%s returned %d: %s", endpoint, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, fmt.Errorf("invalid response from %s: %w", endpoint, err)
+	}
+	if values.Get("oauth_token") == "" {
+		return nil, fmt.Errorf("no oauth_token in response from %s", endpoint)
+	}
+	return values, nil
+}
+
+// newSigner returns a signer for the current Key and Secret. It's built per
+// request, so a Config can be used concurrently and its credentials changed.
+func (c *Config) newSigner() *signer {
+	return &signer{
+		consumerKey:    c.Key,
+		consumerSecret: c.Secret,
+		now:            time.Now,
+		nonce:          randomNonce,
+	}
+}
+
+func (c *Config) requestTokenURL() string {
+	if c.RequestTokenURL != "" {
+		return c.RequestTokenURL
+	}
+	return DefaultRequestTokenURL
+}
+
+func (c *Config) authorizeURL() string {
+	if c.AuthorizeURL != "" {
+		return c.AuthorizeURL
+	}
+	return DefaultAuthorizeURL
+}
+
+func (c *Config) accessTokenURL() string {
+	if c.AccessTokenURL != "" {
+		return c.AccessTokenURL
+	}
+	return DefaultAccessTokenURL
+}
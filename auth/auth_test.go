@@ -0,0 +1,197 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testKey    = "appkey"
+	testSecret = "appsecret"
+)
+
+// stubOAuthServer is a stand-in for Trello's OAuth 1.0a endpoints. It checks
+// the signature of every token request and remembers the callback URL so
+// tests can play the part of the user's browser.
+type stubOAuthServer struct {
+	t           *testing.T
+	server      *httptest.Server
+	callbackURL string
+}
+
+func newStubOAuthServer(t *testing.T) *stubOAuthServer {
+	s := &stubOAuthServer{t: t}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/OAuthGetRequestToken", func(w http.ResponseWriter, r *http.Request) {
+		params := s.verify(r, "")
+		s.callbackURL = params["oauth_callback"]
+		fmt.Fprint(w, "oauth_token=reqtoken&oauth_token_secret=reqsecret&oauth_callback_confirmed=true")
+	})
+	mux.HandleFunc("/OAuthGetAccessToken", func(w http.ResponseWriter, r *http.Request) {
+		params := s.verify(r, "reqsecret")
+		if params["oauth_token"] != "reqtoken" || params["oauth_verifier"] != "verif" {
+			http.Error(w, "invalid verifier", http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, "oauth_token=accesstoken&oauth_token_secret=accesssecret")
+	})
+	s.server = httptest.NewServer(mux)
+	t.Cleanup(s.server.Close)
+	return s
+}
+
+func (s *stubOAuthServer) config() *Config {
+	return &Config{
+		Key:             testKey,
+		Secret:          testSecret,
+		Name:            "Test App",
+		Scope:           []string{ScopeRead, ScopeWrite},
+		Expiration:      Expiration1Day,
+		RequestTokenURL: s.server.URL + "/OAuthGetRequestToken",
+		AuthorizeURL:    s.server.URL + "/OAuthAuthorizeToken",
+		AccessTokenURL:  s.server.URL + "/OAuthGetAccessToken",
+	}
+}
+
+// verify recomputes the signature of r and fails the test if it doesn't
+// match. It returns the oauth parameters of the request.
+func (s *stubOAuthServer) verify(r *http.Request, tokenSecret string) map[string]string {
+	params := map[string]string{}
+	header := strings.TrimPrefix(r.Header.Get("Authorization"), "OAuth ")
+	for _, part := range strings.Split(header, ", ") {
+		k, v, _ := strings.Cut(part, "=")
+		v, _ = url.PathUnescape(strings.Trim(v, `"`))
+		params[k] = v
+	}
+
+	ts, _ := strconv.ParseInt(params["oauth_timestamp"], 10, 64)
+	extra := map[string]string{}
+	for k, v := range params {
+		switch k {
+		case "oauth_callback", "oauth_token", "oauth_verifier":
+			extra[k] = v
+		}
+	}
+	sig := &signer{
+		consumerKey:    testKey,
+		consumerSecret: testSecret,
+		now:            func() time.Time { return time.Unix(ts, 0) },
+		nonce:          func() string { return params["oauth_nonce"] },
+	}
+	expected, _ := sig.authorization(r.Method, "http://"+r.Host+r.URL.RequestURI(), extra, tokenSecret)
+	if expected != r.Header.Get("Authorization") {
+		s.t.Errorf("Signature mismatch.\nExpected: %s\nGot:      %s", expected, r.Header.Get("Authorization"))
+	}
+	if params["oauth_consumer_key"] != testKey {
+		s.t.Errorf("Unexpected consumer key '%s'.", params["oauth_consumer_key"])
+	}
+	return params
+}
+
+func TestAuthorizationURL(t *testing.T) {
+	cfg := &Config{Key: testKey, Name: "Release Bot", Scope: []string{ScopeRead, ScopeWrite}, Expiration: ExpirationNever}
+	u, err := url.Parse(cfg.AuthorizationURL(&RequestToken{Token: "reqtoken"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Host != "trello.com" || u.Path != "/1/OAuthAuthorizeToken" {
+		t.Errorf("Unexpected authorization URL '%s'.", u)
+	}
+	q := u.Query()
+	if q.Get("oauth_token") != "reqtoken" || q.Get("scope") != "read,write" || q.Get("expiration") != "never" || q.Get("name") != "Release Bot" {
+		t.Errorf("Unexpected authorization URL parameters '%s'.", u.RawQuery)
+	}
+}
+
+func TestAuthorizationURLDefaults(t *testing.T) {
+	cfg := &Config{Key: testKey}
+	u, _ := url.Parse(cfg.AuthorizationURL(&RequestToken{Token: "reqtoken"}))
+	if u.Query().Get("scope") != "read" || u.Query().Get("expiration") != "30days" {
+		t.Errorf("Unexpected default parameters '%s'.", u.RawQuery)
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	stub := newStubOAuthServer(t)
+	cfg := stub.config()
+
+	client, err := cfg.Authorize(context.Background(), func(authorizationURL string) error {
+		u, _ := url.Parse(authorizationURL)
+		if u.Query().Get("oauth_token") != "reqtoken" {
+			t.Errorf("Unexpected authorization URL '%s'.", authorizationURL)
+		}
+		// Play the part of the browser, which Trello redirects to the callback.
+		go func() {
+			resp, err := http.Get(stub.callbackURL + "?oauth_token=reqtoken&oauth_verifier=verif")
+			if err == nil {
+				resp.Body.Close()
+			}
+		}()
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.Key != testKey || client.Token != "accesstoken" {
+		t.Errorf("Expected a client with key '%s' and token 'accesstoken'. Got '%s' and '%s'.", testKey, client.Key, client.Token)
+	}
+	if !strings.HasPrefix(stub.callbackURL, "http://127.0.0.1:") {
+		t.Errorf("Expected a local callback URL. Got '%s'.", stub.callbackURL)
+	}
+	if _, err := http.Get(stub.callbackURL); err == nil {
+		t.Error("The callback server should be shut down after Authorize returns.")
+	}
+}
+
+func TestAuthorizeDenied(t *testing.T) {
+	stub := newStubOAuthServer(t)
+	cfg := stub.config()
+
+	_, err := cfg.Authorize(context.Background(), func(string) error {
+		go func() {
+			resp, err := http.Get(stub.callbackURL + "?oauth_token=reqtoken")
+			if err == nil {
+				resp.Body.Close()
+			}
+		}()
+		return nil
+	})
+	if !errors.Is(err, ErrAccessDenied) {
+		t.Errorf("Expected ErrAccessDenied. Got %v.", err)
+	}
+}
+
+func TestAuthorizeCanceled(t *testing.T) {
+	stub := newStubOAuthServer(t)
+	cfg := stub.config()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := cfg.Authorize(ctx, func(string) error { return nil })
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded. Got %v.", err)
+	}
+}
+
+func TestExchangeRejected(t *testing.T) {
+	stub := newStubOAuthServer(t)
+	cfg := stub.config()
+
+	_, err := cfg.Exchange(context.Background(), &RequestToken{Token: "reqtoken", Secret: "reqsecret"}, "wrong")
+	if err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("Expected a 401 error. Got %v.", err)
+	}
+}
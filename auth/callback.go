@@ -0,0 +1,104 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/adlio/trello"
+)
+
+// callbackPath is where the local server receives Trello's redirect.
+const callbackPath = "/callback"
+
+// ErrAccessDenied is returned by Authorize when the user declines to grant access.
+var ErrAccessDenied = errors.New("the user denied access")
+
+// Authorize runs the whole three-legged flow. It starts a short-lived local
+// http.Server to receive Trello's callback, obtains a RequestToken, and calls
+// open with the URL the user must visit (e.g. to print it or launch a
+// browser). Once the user grants access it exchanges the verifier for an
+// AccessToken and returns a trello.Client configured with it. The server is
+// shut down before Authorize returns. Cancel ctx to abandon the flow.
+func (c *Config) Authorize(ctx context.Context, open func(authorizationURL string) error) (*trello.Client, error) {
+	at, err := c.AuthorizeToken(ctx, open)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client(at), nil
+}
+
+// AuthorizeToken is the same as Authorize, but returns the AccessToken so
+// it can be stored for later use.
+func (c *Config) AuthorizeToken(ctx context.Context, open func(authorizationURL string) error) (*AccessToken, error) {
+	addr := c.CallbackAddr
+	if addr == "" {
+		addr = "127.0.0.1:0"
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("callback server: %w", err)
+	}
+
+	callbackURL := fmt.Sprintf("http://%s%s", listener.Addr(), callbackPath)
+	rt, err := c.GetRequestToken(ctx, callbackURL)
+	if err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	type result struct {
+		verifier string
+		err      error
+	}
+	results := make(chan result, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc(callbackPath, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		var res result
+		switch {
+		case q.Get("oauth_token") != rt.Token:
+			http.Error(w, "Unknown authorization request.", http.StatusBadRequest)
+			return
+		case q.Get("oauth_verifier") == "":
+			res.err = ErrAccessDenied
+			fmt.Fprintln(w, "Access was not granted. You may close this window.")
+		default:
+			res.verifier = q.Get("oauth_verifier")
+			fmt.Fprintln(w, "Access granted. You may close this window.")
+		}
+		select {
+		case results <- res:
+		default:
+		}
+	})
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go server.Serve(listener)
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	if err := open(c.AuthorizationURL(rt)); err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-results:
+		if res.err != nil {
+			return nil, res.err
+		}
+		return c.Exchange(ctx, rt, res.verifier)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// signer produces OAuth 1.0a HMAC-SHA1 Authorization headers as described
+// in RFC 5849.
+type signer struct {
+	consumerKey    string
+	consumerSecret string
+
+	// now and nonce are replaceable so signatures can be tested against
+	// known values.
+	now   func() time.Time
+	nonce func() string
+}
+
+// authorization returns the value of the Authorization header for a request
+// with the given method and URL. The oauth parameters in extra (such as
+// oauth_token or oauth_callback) are included in the signature and header.
+func (s *signer) authorization(method, rawURL string, extra map[string]string, tokenSecret string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	oauthParams := map[string]string{
+		"oauth_consumer_key":     s.consumerKey,
+		"oauth_nonce":            s.nonce(),
+		"oauth_signature_method": "HMAC-SHA1",
+		"oauth_timestamp":        strconv.FormatInt(s.now().Unix(), 10),
+		"oauth_version":          "1.0",
+	}
+	for k, v := range extra {
+		oauthParams[k] = v
+	}
+
+	// The signature covers the oauth parameters along with the query string.
+	var pairs []string
+	for k, v := range oauthParams {
+		pairs = append(pairs, percentEncode(k)+"="+percentEncode(v))
+	}
+	for k, vs := range u.Query() {
+		for _, v := range vs {
+			pairs = append(pairs, percentEncode(k)+"="+percentEncode(v))
+		}
+	}
+	sort.Strings(pairs)
+
+	baseURL := fmt.Sprintf("%s://%s%s", strings.ToLower(u.Scheme), strings.ToLower(u.Host), u.EscapedPath())
+	base := strings.Join([]string{
+		strings.ToUpper(method),
+		percentEncode(baseURL),
+		percentEncode(strings.Join(pairs, "&")),
+	}, "&")
+
+	key := percentEncode(s.consumerSecret) + "&" + percentEncode(tokenSecret)
+	mac := hmac.New(sha1.New, []byte(key))
+	mac.Write([]byte(base))
+	oauthParams["oauth_signature"] = base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	keys := make([]string, 0, len(oauthParams))
+	for k := range oauthParams {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf(`%s="%s"`, percentEncode(k), percentEncode(oauthParams[k])))
+	}
+	return "OAuth " + strings.Join(parts, ", "), nil
+}
+
+// percentEncode encodes s per RFC 3986, leaving only unreserved characters
+// unescaped, as OAuth 1.0a requires.
+func percentEncode(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') ||
+			c == '-' || c == '.' || c == '_' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func randomNonce() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,48 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSignerRFC5849Example checks the signature against the example in
+// section 1.2 of RFC 5849.
+func TestSignerRFC5849Example(t *testing.T) {
+	s := &signer{
+		consumerKey:    "dpf43f3p2l4k3l03",
+		consumerSecret: "kd94hf93k423kf44",
+		now:            func() time.Time { return time.Unix(1191242096, 0) },
+		nonce:          func() string { return "kllo9940pd9333jh" },
+	}
+	header, err := s.authorization("GET", "http://photos.example.net/photos?file=vacation.jpg&size=original",
+		map[string]string{"oauth_token": "nnch734d00sl2jdk"}, "pfkkdhi9sl3r4s00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(header, `oauth_signature="tR3%2BTy81lMeYAr%2FFid0kMTYa%2FWM%3D"`) {
+		t.Errorf("Unexpected signature in header:\n%s", header)
+	}
+	if !strings.HasPrefix(header, "OAuth ") {
+		t.Errorf("Expected an OAuth header. Got '%s'.", header)
+	}
+}
+
+func TestPercentEncode(t *testing.T) {
+	tests := map[string]string{
+		"abcXYZ019-._~": "abcXYZ019-._~",
+		"a b":           "a%20b",
+		"a+b=c&d":       "a%2Bb%3Dc%26d",
+		"ü":             "%C3%BC",
+	}
+	for in, expected := range tests {
+		if got := percentEncode(in); got != expected {
+			t.Errorf("percentEncode(%q) = %q, expected %q", in, got, expected)
+		}
+	}
+}
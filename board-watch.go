@@ -0,0 +1,171 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package trello
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultWatchInterval is the polling interval Board.Watch uses when it's
+// given an interval of zero.
+const DefaultWatchInterval = time.Minute
+
+// watchPageSize is the number of actions requested per poll. It's Trello's
+// maximum.
+const watchPageSize = 1000
+
+// WatchOptions controls Board.Watch.
+type WatchOptions struct {
+	// Since is the cursor to resume from: the Cursor() of the last event
+	// handled before a restart. When empty, Watch starts from the board's
+	// latest action and delivers only actions which happen after it.
+	Since string
+
+	// Filter restricts the events to the given action types, e.g.
+	// "createCard" or "updateCard".
+	Filter []string
+
+	// OnError is called when a poll fails. Watch keeps polling, backing off
+	// exponentially until a poll succeeds.
+	OnError func(error)
+
+	// MaxBackoff caps the delay between polls after errors. It defaults to
+	// ten times the interval.
+	MaxBackoff time.Duration
+}
+
+// Watch polls the Board's actions every interval and delivers new ones on
+// the returned channel, oldest first, as an alternative to webhooks for
+// consumers which can't receive them. Each action is delivered once, even
+// if polls overlap. Persist the Cursor() of each handled event and pass it
+// as WatchOptions.Since to resume where a previous Watch left off.
+//
+// The channel is closed once ctx is done.
+func (b *Board) Watch(ctx context.Context, interval time.Duration, opts WatchOptions) <-chan ActionEvent {
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+	maxBackoff := opts.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = 10 * interval
+	}
+
+	w := &boardWatcher{
+		board:  &Board{ID: b.ID, client: b.client.WithContext(ctx)},
+		opts:   opts,
+		cursor: opts.Since,
+		seen:   map[string]bool{},
+	}
+	events := make(chan ActionEvent)
+	go func() {
+		defer close(events)
+		delay := time.Duration(0)
+		backoff := interval
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+
+			actions, err := w.poll()
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				if opts.OnError != nil {
+					opts.OnError(err)
+				}
+				delay = backoff
+				backoff = min(2*backoff, maxBackoff)
+				continue
+			}
+			delay, backoff = interval, interval
+
+			for _, action := range actions {
+				select {
+				case events <- ActionEvent{Action: action, IDModel: b.ID, Source: EventSourcePoll}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return events
+}
+
+type boardWatcher struct {
+	board  *Board
+	opts   WatchOptions
+	cursor string
+
+	// seen holds the IDs of the actions delivered by the last poll, which
+	// Trello may return again if the cursor's action was deleted.
+	seen map[string]bool
+}
+
+// poll returns the actions after the cursor, oldest first, and advances
+// the cursor past them.
+func (w *boardWatcher) poll() (ActionCollection, error) {
+	if w.cursor == "" {
+		latest, err := w.board.GetActions(w.args(Arguments{"limit": "1"}))
+		if err != nil {
+			return nil, fmt.Errorf("watching board %s: %w", w.board.ID, err)
+		}
+		// Trello also accepts a date as the cursor, for boards without
+		// any actions yet.
+		w.cursor = time.Now().UTC().Format(time.RFC3339)
+		if len(latest) > 0 {
+			w.cursor = latest[0].ID
+		}
+		return nil, nil
+	}
+
+	var actions ActionCollection
+	args := w.args(Arguments{"limit": strconv.Itoa(watchPageSize), "since": w.cursor})
+	for {
+		page, err := w.board.GetActions(args)
+		if err != nil {
+			return nil, fmt.Errorf("watching board %s: %w", w.board.ID, err)
+		}
+		actions = append(actions, page...)
+		if len(page) < watchPageSize {
+			break
+		}
+		// Trello returns the newest actions first, so a full page may
+		// leave older ones between it and the cursor.
+		sort.Sort(page)
+		args["before"] = page[0].ID
+	}
+
+	sort.Sort(actions)
+	fresh := make(ActionCollection, 0, len(actions))
+	seen := map[string]bool{}
+	for _, action := range actions {
+		if w.seen[action.ID] || seen[action.ID] || action.ID == w.cursor {
+			continue
+		}
+		seen[action.ID] = true
+		fresh = append(fresh, action)
+	}
+	if len(fresh) > 0 {
+		w.cursor = fresh[len(fresh)-1].ID
+		w.seen = seen
+	}
+	return fresh, nil
+}
+
+func (w *boardWatcher) args(args Arguments) Arguments {
+	if len(w.opts.Filter) > 0 {
+		args["filter"] = strings.Join(w.opts.Filter, ",")
+	}
+	return args
+}
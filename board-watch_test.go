@@ -0,0 +1,214 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package trello
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// actionServer serves a board's actions the way Trello does: newest first,
+// after since and before before, up to limit.
+type actionServer struct {
+	sync.Mutex
+	server   *httptest.Server
+	actions  []*Action
+	failures int
+	polls    int
+}
+
+func newActionServer(t *testing.T, count int) *actionServer {
+	s := &actionServer{}
+	s.add(count)
+	s.server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		s.Lock()
+		defer s.Unlock()
+		if r.URL.Path != "/boards/board1/actions" {
+			t.Errorf("Unexpected path %s.", r.URL.Path)
+		}
+		s.polls++
+		if s.failures > 0 {
+			s.failures--
+			http.Error(rw, "try again later", http.StatusTooManyRequests)
+			return
+		}
+		q := r.URL.Query()
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		page := []*Action{}
+		for i := len(s.actions) - 1; i >= 0 && len(page) < limit; i-- {
+			a := s.actions[i]
+			if (q.Get("since") == "" || a.ID > q.Get("since")) && (q.Get("before") == "" || a.ID < q.Get("before")) {
+				page = append(page, a)
+			}
+		}
+		json.NewEncoder(rw).Encode(page)
+	}))
+	return s
+}
+
+func (s *actionServer) add(count int) {
+	for i := 0; i < count; i++ {
+		s.actions = append(s.actions, &Action{ID: fmt.Sprintf("%024x", len(s.actions)+1), Type: "updateCard"})
+	}
+}
+
+func (s *actionServer) board() *Board {
+	c := testClient()
+	c.BaseURL = s.server.URL
+	b := &Board{ID: "board1"}
+	b.SetClient(c)
+	return b
+}
+
+func receive(t *testing.T, events <-chan ActionEvent, count int) []string {
+	var ids []string
+	for len(ids) < count {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				t.Fatalf("Channel closed after %d events.", len(ids))
+			}
+			if e.Source != EventSourcePoll || e.IDModel != "board1" || e.Cursor() != e.Action.ID {
+				t.Errorf("Unexpected event %+v.", e)
+			}
+			ids = append(ids, e.Action.ID)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("Timed out after %d events.", len(ids))
+		}
+	}
+	return ids
+}
+
+func TestBoardWatchResumesFromCursor(t *testing.T) {
+	s := newActionServer(t, 3)
+	defer s.server.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := s.board().Watch(ctx, 5*time.Millisecond, WatchOptions{Since: s.actions[0].ID})
+	ids := receive(t, events, 2)
+	if ids[0] != s.actions[1].ID || ids[1] != s.actions[2].ID {
+		t.Errorf("Expected the actions after the cursor, oldest first. Got %v.", ids)
+	}
+
+	s.Lock()
+	s.add(2)
+	s.Unlock()
+	ids = receive(t, events, 2)
+	if ids[0] != s.actions[3].ID || ids[1] != s.actions[4].ID {
+		t.Errorf("Expected the new actions. Got %v.", ids)
+	}
+
+	select {
+	case e := <-events:
+		t.Errorf("Expected no duplicate events. Got %s.", e.Action.ID)
+	case <-time.After(30 * time.Millisecond):
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestBoardWatchStartsFromLatest(t *testing.T) {
+	s := newActionServer(t, 5)
+	defer s.server.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := s.board().Watch(ctx, 5*time.Millisecond, WatchOptions{})
+	for {
+		s.Lock()
+		polls := s.polls
+		s.Unlock()
+		if polls > 0 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	s.Lock()
+	s.add(1)
+	s.Unlock()
+
+	ids := receive(t, events, 1)
+	if ids[0] != s.actions[5].ID {
+		t.Errorf("Expected only the action added after Watch started. Got %v.", ids)
+	}
+}
+
+func TestBoardWatchPagesThroughBacklog(t *testing.T) {
+	s := newActionServer(t, watchPageSize+250)
+	defer s.server.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := s.board().Watch(ctx, time.Hour, WatchOptions{Since: s.actions[9].ID})
+	ids := receive(t, events, len(s.actions)-10)
+	for i, id := range ids {
+		if id != s.actions[i+10].ID {
+			t.Fatalf("Expected action %d to be %s. Got %s.", i, s.actions[i+10].ID, id)
+		}
+	}
+}
+
+func TestBoardWatchBacksOffOnErrors(t *testing.T) {
+	s := newActionServer(t, 2)
+	s.failures = 3
+	defer s.server.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var mu sync.Mutex
+	var errs []error
+	start := time.Now()
+	events := s.board().Watch(ctx, 10*time.Millisecond, WatchOptions{
+		Since:      s.actions[0].ID,
+		MaxBackoff: 25 * time.Millisecond,
+		OnError: func(err error) {
+			mu.Lock()
+			errs = append(errs, err)
+			mu.Unlock()
+		},
+	})
+	ids := receive(t, events, 1)
+	if ids[0] != s.actions[1].ID {
+		t.Errorf("Expected the action after the cursor. Got %v.", ids)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(errs) != 3 {
+		t.Errorf("Expected 3 errors. Got %d.", len(errs))
+	}
+	// The retries wait 10ms, 20ms and then 25ms (capped).
+	if elapsed := time.Since(start); elapsed < 55*time.Millisecond {
+		t.Errorf("Expected the retries to back off. Took %s.", elapsed)
+	}
+}
+
+func TestBoardWatchClosesOnCancel(t *testing.T) {
+	s := newActionServer(t, 1)
+	defer s.server.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	events := s.board().Watch(ctx, time.Millisecond, WatchOptions{})
+	cancel()
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Error("Expected no events after cancel.")
+		}
+	case <-time.After(time.Second):
+		t.Error("Expected the channel to be closed.")
+	}
+}
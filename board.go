@@ -7,28 +7,127 @@ package trello
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
+// BoardPermissionLevel is who can see a Board.
+type BoardPermissionLevel string
+
+// Board permission levels.
+const (
+	PermissionLevelPrivate    BoardPermissionLevel = "private"
+	PermissionLevelOrg        BoardPermissionLevel = "org"
+	PermissionLevelPublic     BoardPermissionLevel = "public"
+	PermissionLevelEnterprise BoardPermissionLevel = "enterprise"
+)
+
+// BoardAccess is who can vote or comment on a Board's cards.
+type BoardAccess string
+
+// Board voting and commenting access.
+const (
+	BoardAccessDisabled  BoardAccess = "disabled"
+	BoardAccessMembers   BoardAccess = "members"
+	BoardAccessObservers BoardAccess = "observers"
+	BoardAccessOrg       BoardAccess = "org"
+	BoardAccessPublic    BoardAccess = "public"
+)
+
+// BoardInvitations is who can invite people to a Board.
+type BoardInvitations string
+
+// Board invitation policies.
+const (
+	InvitationsAdmins  BoardInvitations = "admins"
+	InvitationsMembers BoardInvitations = "members"
+)
+
+// BoardCardAging is how a Board shows cards which haven't been active.
+type BoardCardAging string
+
+// Card aging styles.
+const (
+	CardAgingRegular BoardCardAging = "regular"
+	CardAgingPirate  BoardCardAging = "pirate"
+)
+
+// Colors accepted by Board.SetBackground.
+const (
+	BackgroundBlue   = "blue"
+	BackgroundOrange = "orange"
+	BackgroundGreen  = "green"
+	BackgroundRed    = "red"
+	BackgroundPurple = "purple"
+	BackgroundPink   = "pink"
+	BackgroundLime   = "lime"
+	BackgroundSky    = "sky"
+	BackgroundGrey   = "grey"
+)
+
+// KeepFromSourceCards is passed to Board.Copy to copy the source board's
+// cards along with its lists.
+const KeepFromSourceCards = "cards"
+
 type BoardPrefs struct {
-	PermissionLevel       string            `json:"permissionLevel"`
-	Voting                string            `json:"voting"`
-	Comments              string            `json:"comments"`
-	Invitations           string            `json:"invitations"`
-	SelfJoin              bool              `json:"selfjoin"`
-	CardCovers            bool              `json:"cardCovers"`
-	CardAging             string            `json:"cardAging"`
-	CalendarFeedEnabled   bool              `json:"calendarFeedEnabled"`
-	Background            string            `json:"background"`
-	BackgroundColor       string            `json:"backgroundColor"`
-	BackgroundImage       string            `json:"backgroundImage"`
-	BackgroundImageScaled []BackgroundImage `json:"backgroundImageScaled"`
-	BackgroundTile        bool              `json:"backgroundTile"`
-	BackgroundBrightness  string            `json:"backgroundBrightness"`
-	CanBePublic           bool              `json:"canBePublic"`
-	CanBeOrg              bool              `json:"canBeOrg"`
-	CanBePrivate          bool              `json:"canBePrivate"`
-	CanInvite             bool              `json:"canInvite"`
+	PermissionLevel       BoardPermissionLevel `json:"permissionLevel"`
+	Voting                BoardAccess          `json:"voting"`
+	Comments              BoardAccess          `json:"comments"`
+	Invitations           BoardInvitations     `json:"invitations"`
+	SelfJoin              bool                 `json:"selfjoin"`
+	CardCovers            bool                 `json:"cardCovers"`
+	CardAging             BoardCardAging       `json:"cardAging"`
+	CalendarFeedEnabled   bool                 `json:"calendarFeedEnabled"`
+	Background            string               `json:"background"`
+	BackgroundColor       string               `json:"backgroundColor"`
+	BackgroundImage       string               `json:"backgroundImage"`
+	BackgroundImageScaled []BackgroundImage    `json:"backgroundImageScaled"`
+	BackgroundTile        bool                 `json:"backgroundTile"`
+	BackgroundBrightness  string               `json:"backgroundBrightness"`
+	CanBePublic           bool                 `json:"canBePublic"`
+	CanBeOrg              bool                 `json:"canBeOrg"`
+	CanBePrivate          bool                 `json:"canBePrivate"`
+	CanInvite             bool                 `json:"canInvite"`
+}
+
+// BoardPrefsUpdate holds changes to a Board's preferences for
+// Board.SetPrefs. Zero values (empty strings and nil pointers) leave the
+// preference as it is.
+type BoardPrefsUpdate struct {
+	PermissionLevel     BoardPermissionLevel
+	Voting              BoardAccess
+	Comments            BoardAccess
+	Invitations         BoardInvitations
+	CardAging           BoardCardAging
+	Background          string
+	SelfJoin            *bool
+	CardCovers          *bool
+	CalendarFeedEnabled *bool
+}
+
+// ToArguments returns the changes as Arguments for a board PUT.
+func (u BoardPrefsUpdate) ToArguments() Arguments {
+	args := Arguments{}
+	set := func(key, value string) {
+		if value != "" {
+			args["prefs/"+key] = value
+		}
+	}
+	setBool := func(key string, value *bool) {
+		if value != nil {
+			args["prefs/"+key] = fmt.Sprintf("%t", *value)
+		}
+	}
+	set("permissionLevel", string(u.PermissionLevel))
+	set("voting", string(u.Voting))
+	set("comments", string(u.Comments))
+	set("invitations", string(u.Invitations))
+	set("cardAging", string(u.CardAging))
+	set("background", u.Background)
+	setBool("selfJoin", u.SelfJoin)
+	setBool("cardCovers", u.CardCovers)
+	setBool("calendarFeedEnabled", u.CalendarFeedEnabled)
+	return args
 }
 
 type BoardLabelNames struct {
@@ -115,7 +214,7 @@ func (b *Board) CreatedAt() time.Time {
 
 // CreateBoard creates a board remote.
 // Attribute currently supported as extra argument: defaultLists, powerUps.
-// Attributes currently known to be unsupported: idBoardSource, keepFromSource.
+// Use Board.Copy to create a board from an existing one.
 //
 // API Docs: https://developers.trello.com/reference/#boardsid
 func (c *Client) CreateBoard(board *Board, extraArgs ...Arguments) error {
@@ -129,22 +228,22 @@ func (c *Client) CreateBoard(board *Board, extraArgs ...Arguments) error {
 	}
 
 	if board.Prefs.Voting != "" {
-		args["prefs_voting"] = board.Prefs.Voting
+		args["prefs_voting"] = string(board.Prefs.Voting)
 	}
 	if board.Prefs.PermissionLevel != "" {
-		args["prefs_permissionLevel"] = board.Prefs.PermissionLevel
+		args["prefs_permissionLevel"] = string(board.Prefs.PermissionLevel)
 	}
 	if board.Prefs.Comments != "" {
-		args["prefs_comments"] = board.Prefs.Comments
+		args["prefs_comments"] = string(board.Prefs.Comments)
 	}
 	if board.Prefs.Invitations != "" {
-		args["prefs_invitations"] = board.Prefs.Invitations
+		args["prefs_invitations"] = string(board.Prefs.Invitations)
 	}
 	if board.Prefs.Background != "" {
 		args["prefs_background"] = board.Prefs.Background
 	}
 	if board.Prefs.CardAging != "" {
-		args["prefs_cardAging"] = board.Prefs.CardAging
+		args["prefs_cardAging"] = string(board.Prefs.CardAging)
 	}
 
 	args.flatten(extraArgs)
@@ -163,6 +262,105 @@ func (b *Board) Update(extraArgs ...Arguments) error {
 	return b.client.PutBoard(b, args)
 }
 
+// SetPrefs PUTs the changed preferences and updates the Board from the
+// returned values.
+//
+//	err := board.SetPrefs(trello.BoardPrefsUpdate{
+//		PermissionLevel: trello.PermissionLevelOrg,
+//		Voting:          trello.BoardAccessMembers,
+//	})
+func (b *Board) SetPrefs(prefs BoardPrefsUpdate) error {
+	return b.put(prefs.ToArguments())
+}
+
+// Close archives the Board.
+func (b *Board) Close() error {
+	return b.put(Arguments{"closed": "true"})
+}
+
+// Reopen restores a closed Board.
+func (b *Board) Reopen() error {
+	return b.put(Arguments{"closed": "false"})
+}
+
+// Star stars the Board for the user authenticating the API call.
+func (b *Board) Star() error {
+	me := &Member{ID: "me", client: b.client}
+	if _, err := me.StarBoard(b.ID); err != nil {
+		return err
+	}
+	b.Starred = true
+	return nil
+}
+
+// Unstar removes the Board from the starred boards of the user
+// authenticating the API call.
+func (b *Board) Unstar() error {
+	me := &Member{ID: "me", client: b.client}
+	if err := me.UnstarBoard(b.ID); err != nil {
+		return err
+	}
+	b.Starred = false
+	return nil
+}
+
+// SetBackground sets the Board's background to one of the Background
+// colors, or to the ID of a background image.
+func (b *Board) SetBackground(background string) error {
+	return b.put(Arguments{"prefs/background": background})
+}
+
+// SetBackgroundImage uploads an image as a custom background of the user
+// authenticating the API call, and sets it as the Board's background.
+func (b *Board) SetBackgroundImage(upload *Upload) error {
+	var background struct {
+		ID string `json:"id"`
+	}
+	err := b.client.PostUpload("members/me/customBoardBackgrounds", Arguments{}, &background, upload)
+	if err != nil {
+		return err
+	}
+	return b.SetBackground(background.ID)
+}
+
+// MoveToOrganization moves the Board into the Organization with the given ID.
+func (b *Board) MoveToOrganization(orgID string) error {
+	return b.put(Arguments{"idOrganization": orgID})
+}
+
+// Copy creates a new Board named name from this one, in the same
+// Organization. The lists are always copied; pass KeepFromSourceCards to
+// copy the cards too.
+func (b *Board) Copy(name string, keepFromSource ...string) (*Board, error) {
+	board := &Board{Name: name}
+	args := Arguments{
+		"name":           name,
+		"idBoardSource":  b.ID,
+		"keepFromSource": "none",
+	}
+	if len(keepFromSource) > 0 {
+		args["keepFromSource"] = strings.Join(keepFromSource, ",")
+	}
+	if b.IDOrganization != "" {
+		args["idOrganization"] = b.IDOrganization
+	}
+	err := b.client.Post("boards", args, board)
+	if err != nil {
+		return nil, err
+	}
+	board.SetClient(b.client)
+	return board, nil
+}
+
+func (b *Board) put(args Arguments) error {
+	path := fmt.Sprintf("boards/%s", b.ID)
+	err := b.client.Put(path, args, b)
+	if err == nil {
+		b.SetClient(b.client)
+	}
+	return err
+}
+
 // Delete makes a DELETE call for the receiver Board.
 func (b *Board) Delete(extraArgs ...Arguments) error {
 	args := flattenArguments(extraArgs)
@@ -238,22 +436,22 @@ func (c *Client) PutBoard(board *Board, extraArgs ...Arguments) error {
 	}
 
 	if board.Prefs.Voting != "" {
-		args["prefs/voting"] = board.Prefs.Voting
+		args["prefs/voting"] = string(board.Prefs.Voting)
 	}
 	if board.Prefs.PermissionLevel != "" {
-		args["prefs/permissionLevel"] = board.Prefs.PermissionLevel
+		args["prefs/permissionLevel"] = string(board.Prefs.PermissionLevel)
 	}
 	if board.Prefs.Comments != "" {
-		args["prefs/comments"] = board.Prefs.Comments
+		args["prefs/comments"] = string(board.Prefs.Comments)
 	}
 	if board.Prefs.Invitations != "" {
-		args["prefs/invitations"] = board.Prefs.Invitations
+		args["prefs/invitations"] = string(board.Prefs.Invitations)
 	}
 	if board.Prefs.Background != "" {
 		args["prefs/background"] = board.Prefs.Background
 	}
 	if board.Prefs.CardAging != "" {
-		args["prefs/cardAging"] = board.Prefs.CardAging
+		args["prefs/cardAging"] = string(board.Prefs.CardAging)
 	}
 
 	args.flatten(extraArgs)
@@ -6,6 +6,11 @@
 package trello
 
 import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 )
@@ -204,13 +209,13 @@ func TestBoardUpdate(t *testing.T) {
 	if board.Desc != expected["created"]["description"] {
 		t.Errorf("Expected board description. Instead got '%s'.", board.Desc)
 	}
-	if board.Prefs.CardAging != expected["created"]["cardAging"] {
+	if string(board.Prefs.CardAging) != expected["created"]["cardAging"] {
 		t.Errorf("Expected board's card aging. Instead got '%s'.", board.Prefs.CardAging)
 	}
 
 	board.Name = expected["updated"]["name"]
 	board.Desc = expected["updated"]["description"]
-	board.Prefs.CardAging = expected["updated"]["cardAging"]
+	board.Prefs.CardAging = BoardCardAging(expected["updated"]["cardAging"])
 
 	boardResponse = mockResponse("boards", "5d2ccd3015468d3df508f10d", "update.json")
 	client.BaseURL = boardResponse.URL
@@ -229,7 +234,7 @@ func TestBoardUpdate(t *testing.T) {
 	if board.Desc != expected["updated"]["description"] {
 		t.Errorf("Expected board description. Instead got '%s'.", board.Desc)
 	}
-	if board.Prefs.CardAging != expected["updated"]["cardAging"] {
+	if string(board.Prefs.CardAging) != expected["updated"]["cardAging"] {
 		t.Errorf("Expected board's card aging. Instead got '%s'.", board.Prefs.CardAging)
 	}
 }
@@ -293,6 +298,178 @@ func TestBoardAddMember(t *testing.T) {
 	}
 }
 
+func TestBoardPrefsUpdateToArguments(t *testing.T) {
+	enabled := true
+	args := BoardPrefsUpdate{
+		PermissionLevel:     PermissionLevelOrg,
+		Voting:              BoardAccessMembers,
+		CardAging:           CardAgingPirate,
+		CalendarFeedEnabled: &enabled,
+	}.ToArguments()
+	expected := Arguments{
+		"prefs/permissionLevel":     "org",
+		"prefs/voting":              "members",
+		"prefs/cardAging":           "pirate",
+		"prefs/calendarFeedEnabled": "true",
+	}
+	if len(args) != len(expected) {
+		t.Errorf("Expected %v. Got %v.", expected, args)
+	}
+	for k, v := range expected {
+		if args[k] != v {
+			t.Errorf("Expected %s=%s. Got '%s'.", k, v, args[k])
+		}
+	}
+}
+
+func TestBoardSetPrefs(t *testing.T) {
+	c := testClient()
+	server := NewMockResponder(t, "boards", "5d2ccd3015468d3df508f10d", "prefs-updated.json")
+	server.AssertRequest(func(t *testing.T, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/boards/5d2ccd3015468d3df508f10d" {
+			t.Errorf("Unexpected request %s %s.", r.Method, r.URL.Path)
+		}
+		if r.FormValue("prefs/permissionLevel") != "org" || r.FormValue("name") != "" {
+			t.Errorf("Expected only the changed prefs to be sent. Got %v.", r.Form)
+		}
+	})
+	defer server.Close()
+	c.BaseURL = server.URL()
+
+	board := &Board{ID: "5d2ccd3015468d3df508f10d"}
+	board.SetClient(c)
+	if err := board.SetPrefs(BoardPrefsUpdate{PermissionLevel: PermissionLevelOrg}); err != nil {
+		t.Fatal(err)
+	}
+	if board.Prefs.PermissionLevel != PermissionLevelOrg || board.Prefs.Voting != BoardAccessMembers {
+		t.Errorf("Expected the board's prefs to be updated. Got %+v.", board.Prefs)
+	}
+}
+
+func TestBoardLifecycle(t *testing.T) {
+	c := testClient()
+	server := NewMockResponder(t, "boards", "5d2ccd3015468d3df508f10d", "closed.json")
+	var sent []string
+	server.AssertRequest(func(t *testing.T, r *http.Request) {
+		r.ParseForm()
+		sent = append(sent, r.Method+" "+r.URL.Path+" "+r.Form.Encode())
+	})
+	defer server.Close()
+	c.BaseURL = server.URL()
+
+	board := &Board{ID: "5d2ccd3015468d3df508f10d"}
+	board.SetClient(c)
+	if err := board.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !board.Closed {
+		t.Error("Expected the board to be closed.")
+	}
+	if err := board.Reopen(); err != nil {
+		t.Fatal(err)
+	}
+	if err := board.SetBackground(BackgroundGreen); err != nil {
+		t.Fatal(err)
+	}
+	if err := board.MoveToOrganization("571ab6ad9dc91c597d6e9f90"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"PUT /boards/5d2ccd3015468d3df508f10d closed=true",
+		"PUT /boards/5d2ccd3015468d3df508f10d closed=false",
+		"PUT /boards/5d2ccd3015468d3df508f10d prefs%2Fbackground=green",
+		"PUT /boards/5d2ccd3015468d3df508f10d idOrganization=571ab6ad9dc91c597d6e9f90",
+	}
+	if strings.Join(sent, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected requests:\n%s\nGot:\n%s", strings.Join(expected, "\n"), strings.Join(sent, "\n"))
+	}
+}
+
+func TestBoardStar(t *testing.T) {
+	c := testClient()
+	server := NewMockResponder(t, "members", "board-star.json")
+	server.AssertRequest(func(t *testing.T, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/members/me/boardStars" || r.FormValue("idBoard") != "5d2ccd3015468d3df508f10d" {
+			t.Errorf("Unexpected request %s %s %v.", r.Method, r.URL.Path, r.Form)
+		}
+	})
+	defer server.Close()
+	c.BaseURL = server.URL()
+
+	board := &Board{ID: "5d2ccd3015468d3df508f10d"}
+	board.SetClient(c)
+	if err := board.Star(); err != nil {
+		t.Fatal(err)
+	}
+	if !board.Starred {
+		t.Error("Expected the board to be starred.")
+	}
+}
+
+func TestBoardSetBackgroundImage(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/members/me/customBoardBackgrounds":
+			file, header, err := r.FormFile("file")
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, _ := io.ReadAll(file)
+			requests = append(requests, fmt.Sprintf("upload %s %s", header.Filename, body))
+			rw.Write([]byte(`{"id":"60d1e2f3a4b5c6d7e8f90a1b","brightness":"dark","tile":false}`))
+		default:
+			requests = append(requests, fmt.Sprintf("%s %s %s", r.Method, r.URL.Path, r.FormValue("prefs/background")))
+			rw.Write([]byte(`{"id":"5d2ccd3015468d3df508f10d","prefs":{"background":"60d1e2f3a4b5c6d7e8f90a1b"}}`))
+		}
+	}))
+	defer server.Close()
+
+	c := testClient()
+	c.BaseURL = server.URL
+	board := &Board{ID: "5d2ccd3015468d3df508f10d"}
+	board.SetClient(c)
+	err := board.SetBackgroundImage(&Upload{Filename: "mountains.png", Reader: strings.NewReader("png-bytes")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "upload mountains.png png-bytes,PUT /boards/5d2ccd3015468d3df508f10d 60d1e2f3a4b5c6d7e8f90a1b"
+	if strings.Join(requests, ",") != expected {
+		t.Errorf("Expected %s. Got %s.", expected, strings.Join(requests, ","))
+	}
+	if board.Prefs.Background != "60d1e2f3a4b5c6d7e8f90a1b" {
+		t.Errorf("Expected the uploaded background. Got '%s'.", board.Prefs.Background)
+	}
+}
+
+func TestBoardCopy(t *testing.T) {
+	c := testClient()
+	server := NewMockResponder(t, "boards", "5d2ccd3015468d3df508f10d", "copied.json")
+	server.AssertRequest(func(t *testing.T, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/boards" {
+			t.Errorf("Unexpected request %s %s.", r.Method, r.URL.Path)
+		}
+		if r.FormValue("idBoardSource") != "5d2ccd3015468d3df508f10d" || r.FormValue("keepFromSource") != "cards" ||
+			r.FormValue("name") != "Sprint 15" || r.FormValue("idOrganization") != "571ab6ad9dc91c597d6e9f90" {
+			t.Errorf("Unexpected arguments %v.", r.Form)
+		}
+	})
+	defer server.Close()
+	c.BaseURL = server.URL()
+
+	source := &Board{ID: "5d2ccd3015468d3df508f10d", IDOrganization: "571ab6ad9dc91c597d6e9f90"}
+	source.SetClient(c)
+	board, err := source.Copy("Sprint 15", KeepFromSourceCards)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if board.ID != "60c1d2e3f405162738495a6b" || board.Name != "Sprint 15" || board.client != c {
+		t.Errorf("Unexpected copy %+v.", board)
+	}
+}
+
 func TestBoardSetClient(t *testing.T) {
 	board := testBoard(t)
 	client := testClient()
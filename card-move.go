@@ -0,0 +1,211 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package trello
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// MoveToBoardOptions controls how Card.MoveToBoard carries a card's labels
+// over to the target board.
+type MoveToBoardOptions struct {
+	// CreateMissingLabels creates a label on the target board for each of
+	// the card's labels which has no match there, instead of dropping it.
+	CreateMissingLabels bool
+
+	// Pos is the card's position in the target list: "top", "bottom" or a
+	// number. It defaults to Trello's placement.
+	Pos string
+}
+
+// MoveToBoardReport lists what Card.MoveToBoard couldn't carry over to the
+// target board.
+type MoveToBoardReport struct {
+	// UnmappedLabels are the source board's labels with no label of the
+	// same name and color on the target board.
+	UnmappedLabels []*Label
+
+	// UnmappedMemberIDs are the card's members who aren't members of the
+	// target board.
+	UnmappedMemberIDs []string
+
+	// UnmappedCustomFields are the source board's fields which had a value
+	// on the card, but no field of the same name and type (or, for list
+	// fields, no option with the same text) on the target board.
+	UnmappedCustomFields []*CustomField
+}
+
+// Complete reports whether everything was carried over.
+func (r *MoveToBoardReport) Complete() bool {
+	return len(r.UnmappedLabels) == 0 && len(r.UnmappedMemberIDs) == 0 && len(r.UnmappedCustomFields) == 0
+}
+
+// MoveToBoard moves the card to a list on another board. Labels, members
+// and custom fields belong to a board, so Trello drops them when a card
+// changes boards. MoveToBoard carries them over instead: labels are matched
+// by name and color, members are kept if they're members of the target
+// board, and custom field values are copied to fields with the same name
+// and type. The report lists what couldn't be carried over.
+func (c *Card) MoveToBoard(board *Board, list *List, opts MoveToBoardOptions) (*MoveToBoardReport, error) {
+	if list.IDBoard != "" && list.IDBoard != board.ID {
+		return nil, fmt.Errorf("list %s is not on board %s", list.ID, board.ID)
+	}
+	source := &Board{ID: c.IDBoard, client: c.client}
+	target := &Board{ID: board.ID, client: c.client}
+	report := &MoveToBoardReport{}
+
+	labelIDs, err := c.mapLabels(source, target, opts, report)
+	if err != nil {
+		return nil, err
+	}
+	memberIDs, err := c.mapMembers(target, report)
+	if err != nil {
+		return nil, err
+	}
+	values, err := c.mapCustomFields(source, target, report)
+	if err != nil {
+		return nil, err
+	}
+
+	args := Arguments{
+		"idBoard":   board.ID,
+		"idList":    list.ID,
+		"idLabels":  strings.Join(labelIDs, ","),
+		"idMembers": strings.Join(memberIDs, ","),
+	}
+	if opts.Pos != "" {
+		args["pos"] = opts.Pos
+	}
+	if err := c.Update(args); err != nil {
+		return nil, err
+	}
+
+	for _, v := range values {
+		if v.optionID != "" {
+			err = c.SetCustomFieldOption(v.field.ID, v.optionID)
+		} else {
+			err = c.SetCustomFieldValue(v.field.ID, v.value)
+		}
+		if err != nil {
+			return report, fmt.Errorf("card moved, but setting custom field '%s' failed: %w", v.field.Name, err)
+		}
+	}
+	return report, nil
+}
+
+// mappedValue is a custom field value to set on the target board.
+type mappedValue struct {
+	field    *CustomField
+	value    interface{}
+	optionID string
+}
+
+func (c *Card) mapLabels(source, target *Board, opts MoveToBoardOptions, report *MoveToBoardReport) ([]string, error) {
+	if len(c.IDLabels) == 0 {
+		return nil, nil
+	}
+	sourceLabels, err := source.GetLabels()
+	if err != nil {
+		return nil, err
+	}
+	targetLabels, err := target.GetLabels()
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, label := range sourceLabels {
+		if !slices.Contains(c.IDLabels, label.ID) {
+			continue
+		}
+		i := slices.IndexFunc(targetLabels, func(l *Label) bool {
+			return l.Name == label.Name && l.Color == label.Color
+		})
+		switch {
+		case i >= 0:
+			ids = append(ids, targetLabels[i].ID)
+		case opts.CreateMissingLabels:
+			created := &Label{Name: label.Name, Color: label.Color}
+			if err := target.CreateLabel(created); err != nil {
+				return nil, err
+			}
+			targetLabels = append(targetLabels, created)
+			ids = append(ids, created.ID)
+		default:
+			report.UnmappedLabels = append(report.UnmappedLabels, label)
+		}
+	}
+	return ids, nil
+}
+
+func (c *Card) mapMembers(target *Board, report *MoveToBoardReport) ([]string, error) {
+	if len(c.IDMembers) == 0 {
+		return nil, nil
+	}
+	members, err := target.GetMembers()
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, id := range c.IDMembers {
+		if slices.ContainsFunc(members, func(m *Member) bool { return m.ID == id }) {
+			ids = append(ids, id)
+		} else {
+			report.UnmappedMemberIDs = append(report.UnmappedMemberIDs, id)
+		}
+	}
+	return ids, nil
+}
+
+func (c *Card) mapCustomFields(source, target *Board, report *MoveToBoardReport) ([]mappedValue, error) {
+	sourceFields, err := source.GetCustomFields()
+	if err != nil || len(sourceFields) == 0 {
+		return nil, err
+	}
+	items, err := c.GetCustomFieldItems()
+	if err != nil || len(items) == 0 {
+		return nil, err
+	}
+	targetFields, err := target.GetCustomFields()
+	if err != nil {
+		return nil, err
+	}
+
+	var values []mappedValue
+	for _, item := range items {
+		i := slices.IndexFunc(sourceFields, func(f *CustomField) bool { return f.ID == item.IDCustomField })
+		if i < 0 {
+			continue
+		}
+		field := sourceFields[i]
+		j := slices.IndexFunc(targetFields, func(f *CustomField) bool {
+			return f.Name == field.Name && f.Type == field.Type
+		})
+		if j < 0 {
+			report.UnmappedCustomFields = append(report.UnmappedCustomFields, field)
+			continue
+		}
+		targetField := targetFields[j]
+
+		if item.IDValue != "" {
+			option := field.OptionByID(item.IDValue)
+			var targetOption *CustomFieldOption
+			if option != nil {
+				targetOption = targetField.OptionByText(option.Value.Text)
+			}
+			if targetOption == nil {
+				report.UnmappedCustomFields = append(report.UnmappedCustomFields, field)
+				continue
+			}
+			values = append(values, mappedValue{field: targetField, optionID: targetOption.ID})
+		} else if item.Value.Get() != nil {
+			values = append(values, mappedValue{field: targetField, value: item.Value.Get()})
+		}
+	}
+	return values, nil
+}
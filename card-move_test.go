@@ -0,0 +1,200 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package trello
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// boardMoveServer serves the source board "src" and target board "dst" for
+// MoveToBoard tests, and records the card updates it receives.
+type boardMoveServer struct {
+	server   *httptest.Server
+	cardArgs map[string]string
+	items    map[string]string
+	created  []string
+}
+
+func newBoardMoveServer(t *testing.T) *boardMoveServer {
+	s := &boardMoveServer{cardArgs: map[string]string{}, items: map[string]string{}}
+	routes := map[string]string{
+		"GET /boards/src/labels": `[
+			{"id": "src-bug", "idBoard": "src", "name": "Bug", "color": "red"},
+			{"id": "src-ux", "idBoard": "src", "name": "UX", "color": "purple"},
+			{"id": "src-legal", "idBoard": "src", "name": "Legal", "color": "black"},
+			{"id": "src-unused", "idBoard": "src", "name": "Unused", "color": "sky"}
+		]`,
+		"GET /boards/dst/labels": `[
+			{"id": "dst-bug", "idBoard": "dst", "name": "Bug", "color": "red"},
+			{"id": "dst-ux", "idBoard": "dst", "name": "UX", "color": "blue"}
+		]`,
+		"GET /boards/dst/members": `[
+			{"id": "member-alice", "username": "alice"},
+			{"id": "member-carol", "username": "carol"}
+		]`,
+		"GET /boards/src/customFields": `[
+			{"id": "src-points", "idModel": "src", "name": "Points", "type": "number"},
+			{"id": "src-priority", "idModel": "src", "name": "Priority", "type": "list", "options": [
+				{"id": "src-high", "idCustomField": "src-priority", "value": {"text": "High"}},
+				{"id": "src-low", "idCustomField": "src-priority", "value": {"text": "Low"}}
+			]},
+			{"id": "src-team", "idModel": "src", "name": "Team", "type": "text"},
+			{"id": "src-severity", "idModel": "src", "name": "Severity", "type": "list", "options": [
+				{"id": "src-sev1", "idCustomField": "src-severity", "value": {"text": "Sev 1"}}
+			]}
+		]`,
+		"GET /boards/dst/customFields": `[
+			{"id": "dst-points", "idModel": "dst", "name": "Points", "type": "number"},
+			{"id": "dst-priority", "idModel": "dst", "name": "Priority", "type": "list", "options": [
+				{"id": "dst-low", "idCustomField": "dst-priority", "value": {"text": "Low"}},
+				{"id": "dst-high", "idCustomField": "dst-priority", "value": {"text": "High"}}
+			]},
+			{"id": "dst-team", "idModel": "dst", "name": "Team", "type": "list", "options": []},
+			{"id": "dst-severity", "idModel": "dst", "name": "Severity", "type": "list", "options": [
+				{"id": "dst-p1", "idCustomField": "dst-severity", "value": {"text": "P1"}}
+			]}
+		]`,
+		"GET /cards/card1/customFieldItems": `[
+			{"id": "i1", "idCustomField": "src-points", "idModel": "card1", "value": {"number": "5"}},
+			{"id": "i2", "idCustomField": "src-priority", "idModel": "card1", "idValue": "src-high"},
+			{"id": "i3", "idCustomField": "src-team", "idModel": "card1", "value": {"text": "Platform"}},
+			{"id": "i4", "idCustomField": "src-severity", "idModel": "card1", "idValue": "src-sev1"}
+		]`,
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		key := r.Method + " " + r.URL.Path
+		switch {
+		case key == "PUT /cards/card1":
+			for k := range r.URL.Query() {
+				s.cardArgs[k] = r.URL.Query().Get(k)
+			}
+			rw.Write([]byte(`{"id": "card1", "idBoard": "dst", "idList": "dst-list"}`))
+		case r.Method == "PUT" && strings.HasPrefix(r.URL.Path, "/cards/card1/customField/"):
+			if r.Header.Get("Content-Type") != "application/json" {
+				t.Errorf("Expected a JSON body. Got %s.", r.Header.Get("Content-Type"))
+			}
+			body, _ := io.ReadAll(r.Body)
+			field := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/cards/card1/customField/"), "/item")
+			s.items[field] = string(body)
+			rw.Write([]byte(`{}`))
+		case key == "POST /boards/dst/labels/":
+			s.created = append(s.created, r.URL.Query().Get("name")+"/"+r.URL.Query().Get("color"))
+			json.NewEncoder(rw).Encode(Label{ID: "dst-new-" + r.URL.Query().Get("name"), Name: r.URL.Query().Get("name")})
+		case routes[key] != "":
+			rw.Write([]byte(routes[key]))
+		default:
+			t.Errorf("Unexpected request %s.", key)
+			http.Error(rw, "not found", http.StatusNotFound)
+		}
+	}))
+	return s
+}
+
+func testMoveCard(c *Client) *Card {
+	card := &Card{
+		ID:        "card1",
+		IDBoard:   "src",
+		IDList:    "src-list",
+		IDLabels:  []string{"src-bug", "src-ux", "src-legal"},
+		IDMembers: []string{"member-alice", "member-bob"},
+	}
+	card.SetClient(c)
+	return card
+}
+
+func TestCardMoveToBoard(t *testing.T) {
+	s := newBoardMoveServer(t)
+	defer s.server.Close()
+	c := testClient()
+	c.BaseURL = s.server.URL
+
+	card := testMoveCard(c)
+	report, err := card.MoveToBoard(&Board{ID: "dst"}, &List{ID: "dst-list", IDBoard: "dst"}, MoveToBoardOptions{Pos: "top"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"idBoard":   "dst",
+		"idList":    "dst-list",
+		"idLabels":  "dst-bug",
+		"idMembers": "member-alice",
+		"pos":       "top",
+	}
+	for k, v := range expected {
+		if s.cardArgs[k] != v {
+			t.Errorf("Expected %s=%s. Got '%s'.", k, v, s.cardArgs[k])
+		}
+	}
+	if card.IDBoard != "dst" {
+		t.Errorf("Expected the card to be updated. Got board %s.", card.IDBoard)
+	}
+
+	if s.items["dst-points"] != `{"value":{"number":"5"}}` {
+		t.Errorf("Expected Points to be carried over. Got %s.", s.items["dst-points"])
+	}
+	if s.items["dst-priority"] != `{"idValue":"dst-high"}` {
+		t.Errorf("Expected Priority to map to the High option. Got %s.", s.items["dst-priority"])
+	}
+	if len(s.items) != 2 {
+		t.Errorf("Expected 2 custom field values to be set. Got %v.", s.items)
+	}
+
+	if report.Complete() {
+		t.Error("Expected an incomplete report.")
+	}
+	var labels []string
+	for _, l := range report.UnmappedLabels {
+		labels = append(labels, l.Name)
+	}
+	if strings.Join(labels, ",") != "UX,Legal" {
+		t.Errorf("Expected UX (wrong color) and Legal to be unmapped. Got %v.", labels)
+	}
+	if strings.Join(report.UnmappedMemberIDs, ",") != "member-bob" {
+		t.Errorf("Expected member-bob to be unmapped. Got %v.", report.UnmappedMemberIDs)
+	}
+	var fields []string
+	for _, f := range report.UnmappedCustomFields {
+		fields = append(fields, f.Name)
+	}
+	if strings.Join(fields, ",") != "Team,Severity" {
+		t.Errorf("Expected Team (wrong type) and Severity (no option) to be unmapped. Got %v.", fields)
+	}
+}
+
+func TestCardMoveToBoardCreatesMissingLabels(t *testing.T) {
+	s := newBoardMoveServer(t)
+	defer s.server.Close()
+	c := testClient()
+	c.BaseURL = s.server.URL
+
+	report, err := testMoveCard(c).MoveToBoard(&Board{ID: "dst"}, &List{ID: "dst-list"}, MoveToBoardOptions{CreateMissingLabels: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(s.created, ",") != "UX/purple,Legal/black" {
+		t.Errorf("Expected the missing labels to be created. Got %v.", s.created)
+	}
+	if s.cardArgs["idLabels"] != "dst-bug,dst-new-UX,dst-new-Legal" {
+		t.Errorf("Expected the created labels to be applied. Got %s.", s.cardArgs["idLabels"])
+	}
+	if len(report.UnmappedLabels) != 0 {
+		t.Errorf("Expected no unmapped labels. Got %d.", len(report.UnmappedLabels))
+	}
+}
+
+func TestCardMoveToBoardRejectsListOnOtherBoard(t *testing.T) {
+	card := testMoveCard(testClient())
+	_, err := card.MoveToBoard(&Board{ID: "dst"}, &List{ID: "other-list", IDBoard: "other"}, MoveToBoardOptions{})
+	if err == nil {
+		t.Error("Expected an error for a list on another board.")
+	}
+}
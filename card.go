@@ -132,6 +132,7 @@ func (c *Card) SetClient(newClient *Client) {
 
 	for _, attachment := range c.Attachments {
 		attachment.SetClient(newClient)
+		attachment.Card = c
 	}
 
 	for _, checklist := range c.Checklists {
@@ -153,7 +154,8 @@ func (c *Card) CreatedAt() time.Time {
 	return t
 }
 
-// CustomFields returns the card's custom fields.
+// CustomFields returns the card's custom fields. Use DecodeCustomFields to
+// read them into a struct instead.
 func (c *Card) CustomFields(boardCustomFields []*CustomField) map[string]interface{} {
 
 	cfm := c.customFieldMap
@@ -368,6 +370,8 @@ func (c *Card) AddComment(comment string, extraArgs ...Arguments) (*Action, erro
 	err := c.client.Post(path, args, &action)
 	if err != nil {
 		err = fmt.Errorf("Error commenting on card %s: %w", c.ID, err)
+	} else {
+		action.SetClient(c.client)
 	}
 	return &action, err
 }
@@ -383,6 +387,9 @@ func (c *Card) AddURLAttachment(attachment *Attachment, extraArgs ...Arguments)
 	err := c.client.Post(path, args, &attachment)
 	if err != nil {
 		err = fmt.Errorf("Error adding attachment to card %s: %w", c.ID, err)
+	} else {
+		attachment.SetClient(c.client)
+		attachment.Card = c
 	}
 	return err
 
@@ -391,20 +398,63 @@ func (c *Card) AddURLAttachment(attachment *Attachment, extraArgs ...Arguments)
 // GetAttachments returns all attachments for a card
 func (c *Card) GetAttachments(args Arguments) (attachments []*Attachment, err error) {
 	path := fmt.Sprintf("cards/%s/attachments", c.ID)
-	c.client.Get(path, args, &attachments)
+	err = c.client.Get(path, args, &attachments)
+	for _, attachment := range attachments {
+		attachment.SetClient(c.client)
+		attachment.Card = c
+	}
+	return
+}
+
+// GetAttachment takes an attachment id and Arguments and returns the
+// matching attachment of the card or an error.
+func (c *Card) GetAttachment(attachmentID string, extraArgs ...Arguments) (attachment *Attachment, err error) {
+	args := flattenArguments(extraArgs)
+	path := fmt.Sprintf("cards/%s/attachments/%s", c.ID, attachmentID)
+	err = c.client.Get(path, args, &attachment)
+	if attachment != nil {
+		attachment.SetClient(c.client)
+		attachment.Card = c
+	}
 	return
 }
 
+// SetCoverAttachment makes the attachment given by attachmentID the cover
+// image of the card.
+func (c *Card) SetCoverAttachment(attachmentID string) error {
+	return c.Update(Arguments{"idAttachmentCover": attachmentID})
+}
+
 // AddFileAttachment takes an Attachment, filename with io.Reader and adds it to the card.
+// The Attachment's Name and MimeType, when set, are sent along with the file.
 func (c *Card) AddFileAttachment(attachment *Attachment, filename string, file io.Reader, extraArgs ...Arguments) error {
+	return c.UploadAttachment(attachment, &Upload{Filename: filename, Reader: file}, extraArgs...)
+}
+
+// UploadAttachment takes an Attachment and an Upload and adds the file to the
+// card. The file is streamed to Trello rather than buffered in memory, and
+// the Upload can report progress as it's sent. The Attachment's Name and
+// MimeType, when set, are sent along with the file; MimeType is also used as
+// the Content-Type of the file unless the Upload overrides it.
+func (c *Card) UploadAttachment(attachment *Attachment, upload *Upload, extraArgs ...Arguments) error {
 	path := fmt.Sprintf("cards/%s/attachments", c.ID)
 	args := Arguments{
 		"name": attachment.Name,
 	}
+	u := *upload
+	if attachment.MimeType != "" {
+		args["mimeType"] = attachment.MimeType
+		if u.ContentType == "" {
+			u.ContentType = attachment.MimeType
+		}
+	}
 	args.flatten(extraArgs)
-	err := c.client.PostWithBody(path, args, &attachment, filename, file)
+	err := c.client.PostUpload(path, args, &attachment, &u)
 	if err != nil {
 		err = fmt.Errorf("Error adding attachment to card %s: %w", c.ID, err)
+	} else {
+		attachment.SetClient(c.client)
+		attachment.Card = c
 	}
 	return err
 }
@@ -598,6 +648,29 @@ func (l *List) GetCards(extraArgs ...Arguments) (cards []*Card, err error) {
 	return
 }
 
+// GetCards returns the Cards the Member is assigned to, across all boards.
+func (m *Member) GetCards(extraArgs ...Arguments) (cards []*Card, err error) {
+	args := flattenArguments(extraArgs)
+	path := fmt.Sprintf("members/%s/cards", m.ID)
+	err = m.client.Get(path, args, &cards)
+	for i := range cards {
+		cards[i].SetClient(m.client)
+	}
+	return
+}
+
+// GetMyCards returns the Cards the user authenticating the API call is
+// assigned to, across all boards.
+func (c *Client) GetMyCards(extraArgs ...Arguments) (cards []*Card, err error) {
+	args := flattenArguments(extraArgs)
+	path := "members/me/cards"
+	err = c.Get(path, args, &cards)
+	for i := range cards {
+		cards[i].SetClient(c)
+	}
+	return
+}
+
 func earliestCardID(cards []*Card) string {
 	if len(cards) == 0 {
 		return ""
@@ -9,10 +9,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"mime/multipart"
 	"net/http"
+	neturl "net/url"
+	"strings"
 	"time"
 
 	"golang.org/x/time/rate"
@@ -23,15 +27,25 @@ const DefaultBaseURL = "https://api.trello.com/1"
 
 // Client is the central object for making API calls. It wraps a http client,
 // context, logger and identity configuration (Key and Token) of the Trello member.
+//
+// The Key and Token are sent in the Authorization header of each request.
+// Set CredentialsInQuery to send them as URL parameters instead. Either way,
+// the Token is redacted from log output and error messages.
+//
+// Set StructuredLogger to receive a structured record of every API call,
+// with its method, path, status, duration and remaining rate limit.
 type Client struct {
-	Client   *http.Client
-	Logger   logger
-	BaseURL  string
-	Key      string
-	Token    string
-	throttle *rate.Limiter
-	testMode bool
-	ctx      context.Context
+	Client             *http.Client
+	Logger             logger
+	StructuredLogger   *slog.Logger
+	BaseURL            string
+	Key                string
+	Token              string
+	CredentialsInQuery bool
+	throttle           *rate.Limiter
+	testMode           bool
+	ctx                context.Context
+	middleware         []Middleware
 }
 
 type logger interface {
@@ -64,9 +78,17 @@ func (c *Client) WithContext(ctx context.Context) *Client {
 
 // Throttle starts receiving throttles from throttle channel each ticker period.
 func (c *Client) Throttle() {
-	if !c.testMode {
-		c.throttle.Wait(c.ctx)
+	c.wait(c.context())
+}
+
+// wait blocks until the rate limiter allows another request, or returns an
+// error if ctx is done first.
+func (c *Client) wait(ctx context.Context) error {
+	if c.testMode {
+		return ctx.Err()
 	}
+	// Trello prohibits more than 10 seconds/second per token
+	return c.throttle.Wait(ctx)
 }
 
 // Get takes a path, Arguments, and a target interface (e.g. Board or Card).
@@ -74,31 +96,7 @@ func (c *Client) Throttle() {
 // Arguments as URL parameters. Then it returns either the target interface
 // updated from the response or an error.
 func (c *Client) Get(path string, args Arguments, target interface{}) error {
-
-	// Trello prohibits more than 10 seconds/second per token
-	c.Throttle()
-
-	params := args.ToURLValues()
-	c.log("[trello] GET %s?%s", path, params.Encode())
-
-	if c.Key != "" {
-		params.Set("key", c.Key)
-	}
-
-	if c.Token != "" {
-		params.Set("token", c.Token)
-	}
-
-	url := fmt.Sprintf("%s/%s", c.BaseURL, path)
-	urlWithParams := fmt.Sprintf("%s?%s", url, params.Encode())
-
-	req, err := http.NewRequest("GET", urlWithParams, nil)
-	if err != nil {
-		return fmt.Errorf("Invalid GET request %s: %w", url, err)
-	}
-	req = req.WithContext(c.ctx)
-
-	return c.do(req, url, target)
+	return c.Do(c.context(), "GET", path, args, target)
 }
 
 // Put takes a path, Arguments, and a target interface (e.g. Board or Card).
@@ -106,30 +104,7 @@ func (c *Client) Get(path string, args Arguments, target interface{}) error {
 // the Arguments as URL parameters. Then it returns either the target interface
 // updated from the response or an error.
 func (c *Client) Put(path string, args Arguments, target interface{}) error {
-
-	// Trello prohibits more than 10 seconds/second per token
-	c.Throttle()
-
-	params := args.ToURLValues()
-	c.log("[trello] PUT %s?%s", path, params.Encode())
-
-	if c.Key != "" {
-		params.Set("key", c.Key)
-	}
-
-	if c.Token != "" {
-		params.Set("token", c.Token)
-	}
-
-	url := fmt.Sprintf("%s/%s", c.BaseURL, path)
-	urlWithParams := fmt.Sprintf("%s?%s", url, params.Encode())
-
-	req, err := http.NewRequest("PUT", urlWithParams, nil)
-	if err != nil {
-		return fmt.Errorf("Invalid PUT request %s: %w", url, err)
-	}
-
-	return c.do(req, url, target)
+	return c.Do(c.context(), "PUT", path, args, target)
 }
 
 // Post takes a path, Arguments, and a target interface (e.g. Board or Card).
@@ -137,30 +112,7 @@ func (c *Client) Put(path string, args Arguments, target interface{}) error {
 // the Arguments as URL parameters. Then it returns either the target interface
 // updated from the response or an error.
 func (c *Client) Post(path string, args Arguments, target interface{}) error {
-
-	// Trello prohibits more than 10 seconds/second per token
-	c.Throttle()
-
-	params := args.ToURLValues()
-	c.log("[trello] POST %s?%s", path, params.Encode())
-
-	if c.Key != "" {
-		params.Set("key", c.Key)
-	}
-
-	if c.Token != "" {
-		params.Set("token", c.Token)
-	}
-
-	url := fmt.Sprintf("%s/%s", c.BaseURL, path)
-	urlWithParams := fmt.Sprintf("%s?%s", url, params.Encode())
-
-	req, err := http.NewRequest("POST", urlWithParams, nil)
-	if err != nil {
-		return fmt.Errorf("Invalid POST request %s: %w", url, err)
-	}
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	return c.do(req, url, target)
+	return c.Do(c.context(), "POST", path, args, target)
 }
 
 // PostWithBody takes a path, Arguments, and a target interface (e.g. Board or Card).
@@ -169,100 +121,249 @@ func (c *Client) Post(path string, args Arguments, target interface{}) error {
 // Then it returns either the target interface
 // updated from the response or an error.
 func (c *Client) PostWithBody(path string, args Arguments, target interface{}, filename string, file io.Reader) error {
+	return c.PostUpload(path, args, target, &Upload{Filename: filename, Reader: file})
+}
 
-	// Trello prohibits more than 10 seconds/second per token
-	c.Throttle()
+// PostUpload takes a path, Arguments, a target interface (e.g. Attachment) and
+// an Upload. It runs a POST request on the Trello API endpoint with the path,
+// uses the Arguments as URL parameters and streams the Upload as the
+// multipart body. Then it returns either the target interface updated from
+// the response or an error.
+func (c *Client) PostUpload(path string, args Arguments, target interface{}, upload *Upload) error {
+	return c.send(&Request{Context: c.context(), Method: "POST", Path: path, Args: args, Target: target, Upload: upload})
+}
 
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
-	part, err := writer.CreateFormFile("file", filename)
+// PutJSON takes a path, Arguments, a body and a target interface. It runs a
+// PUT request on the Trello API endpoint with the path, uses the Arguments
+// as URL parameters and sends the body encoded as JSON, for endpoints which
+// take nested values (e.g. custom field items). Then it returns either the
+// target interface updated from the response or an error.
+func (c *Client) PutJSON(path string, args Arguments, body interface{}, target interface{}) error {
+	return c.send(&Request{Context: c.context(), Method: "PUT", Path: path, Args: args, Target: target, Body: body})
+}
+
+// Delete takes a path, Arguments, and a target interface (e.g. Board or Card).
+// It runs a DELETE request on the Trello API endpoint with the path and uses
+// the Arguments as URL parameters. Then it returns either the target interface
+// updated from the response or an error.
+func (c *Client) Delete(path string, args Arguments, target interface{}) error {
+	return c.Do(c.context(), "DELETE", path, args, target)
+}
+
+// Do is the core used by every API call. It takes a context, an HTTP
+// method, a path, Arguments, and a target interface (e.g. Board or Card).
+// It waits for the rate limiter, runs the request on the Trello API endpoint
+// with the path and uses the Arguments as URL parameters. Then it returns
+// either the target interface updated from the response or an error.
+// Cancelling ctx aborts both the wait and the HTTP round trip.
+//
+// The typed methods (GetCard, Board.GetLists, etc) use the context the
+// Client was given via WithContext(). Use Do directly, or call the typed
+// methods on client.WithContext(ctx), to scope a single call.
+func (c *Client) Do(ctx context.Context, method, path string, args Arguments, target interface{}) error {
+	return c.send(&Request{Context: ctx, Method: method, Path: path, Args: args, Target: target})
+}
+
+// send runs the Request through the Middleware and decodes the response
+// into its Target. The Request's Arguments are copied first, so Middleware
+// can't modify the caller's.
+func (c *Client) send(req *Request) error {
+	req.Args = flattenArguments([]Arguments{req.Args})
+	req.token = c.Token
+	resp, err := c.handler()(req)
 	if err != nil {
 		return err
 	}
-	_, err = io.Copy(part, file)
-	if err != nil {
-		return err
+	if resp == nil {
+		return fmt.Errorf("middleware returned no response for %s %s", req.Method, c.redact(req.Path))
 	}
-	err = writer.Close()
+	err = json.Unmarshal(resp.Body, req.Target)
 	if err != nil {
-		return err
+		return fmt.Errorf("JSON decode failed on %s:\n%s\n%w", c.redact(c.endpoint(req.Path)), c.redact(string(resp.Body)), err)
 	}
+	return nil
+}
 
-	params := args.ToURLValues()
-	c.log("[trello] POST %s?%s", path, params.Encode())
+// roundTrip is the innermost Handler. It waits for the rate limiter and
+// sends the Request to Trello.
+func (c *Client) roundTrip(r *Request) (*Response, error) {
+	err := c.wait(r.Context)
+	if err != nil {
+		return nil, fmt.Errorf("%s request %s canceled: %w", r.Method, c.redact(r.Path), err)
+	}
 
-	if c.Key != "" {
-		params.Set("key", c.Key)
+	params := r.Args.ToURLValues()
+	if c.Logger != nil {
+		c.Logger.Debugf("%s", c.redact(fmt.Sprintf("[trello] %s %s?%s", r.Method, r.Path, params.Encode())))
 	}
 
-	if c.Token != "" {
-		params.Set("token", c.Token)
+	if c.CredentialsInQuery {
+		if c.Key != "" {
+			params.Set("key", c.Key)
+		}
+
+		if c.Token != "" {
+			params.Set("token", c.Token)
+		}
 	}
 
-	url := fmt.Sprintf("%s/%s", c.BaseURL, path)
+	url := c.endpoint(r.Path)
 	urlWithParams := fmt.Sprintf("%s?%s", url, params.Encode())
 
-	req, err := http.NewRequest("POST", urlWithParams, body)
+	var body io.Reader
+	var pw *io.PipeWriter
+	var writer *multipart.Writer
+	switch {
+	case r.Upload != nil:
+		var pr *io.PipeReader
+		pr, pw = io.Pipe()
+		body = pr
+		writer = multipart.NewWriter(pw)
+	case r.Body != nil:
+		b, err := json.Marshal(r.Body)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid %s request body for %s: %w", r.Method, c.redact(url), err)
+		}
+		body = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(r.Context, r.Method, urlWithParams, body)
 	if err != nil {
-		return fmt.Errorf("Invalid POST request %s: %w", url, err)
+		return nil, fmt.Errorf("Invalid %s request %s: %w", r.Method, c.redact(url), err)
+	}
+	if !c.CredentialsInQuery {
+		c.authorize(req)
 	}
-	req.Header.Set("Content-Type", writer.FormDataContentType())
-	return c.do(req, url, target)
-}
 
-// Delete takes a path, Arguments, and a target interface (e.g. Board or Card).
-// It runs a DELETE request on the Trello API endpoint with the path and uses
-// the Arguments as URL parameters. Then it returns either the target interface
-// updated from the response or an error.
-func (c *Client) Delete(path string, args Arguments, target interface{}) error {
+	switch {
+	case r.Upload != nil:
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+
+		// The body is produced while the request is being sent. The HTTP
+		// client closes the body when it's done with it, which unblocks the
+		// writer if the request fails part way through.
+		upload := r.Upload
+		go func() {
+			pw.CloseWithError(upload.writeTo(writer))
+		}()
+	case r.Body != nil:
+		req.Header.Set("Content-Type", "application/json")
+	case r.Method == "POST":
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
 
-	c.Throttle()
+	start := time.Now()
+	resp, err := c.do(req, url)
+	c.logRequest(r, resp, err, time.Since(start))
+	return resp, err
+}
 
-	params := args.ToURLValues()
-	c.log("[trello] DELETE %s?%s", path, params.Encode())
+// endpoint returns the URL of the API path, without any parameters.
+func (c *Client) endpoint(path string) string {
+	return fmt.Sprintf("%s/%s", c.BaseURL, path)
+}
 
-	if c.Key != "" {
-		params.Set("key", c.Key)
+// context returns the Client's context, falling back to the background
+// context for Clients which weren't built with NewClient().
+func (c *Client) context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
 	}
+	return c.ctx
+}
 
+// authorize sets the Authorization header Trello accepts in place of the
+// key and token URL parameters.
+func (c *Client) authorize(req *http.Request) {
+	var parts []string
+	if c.Key != "" {
+		parts = append(parts, fmt.Sprintf(`oauth_consumer_key="%s"`, c.Key))
+	}
 	if c.Token != "" {
-		params.Set("token", c.Token)
+		parts = append(parts, fmt.Sprintf(`oauth_token="%s"`, c.Token))
 	}
+	if len(parts) > 0 {
+		req.Header.Set("Authorization", "OAuth "+strings.Join(parts, ", "))
+	}
+}
 
-	url := fmt.Sprintf("%s/%s", c.BaseURL, path)
-	urlWithParams := fmt.Sprintf("%s?%s", url, params.Encode())
+// redact removes the Token from s. Some endpoints (e.g. tokens/{token})
+// carry the Token in their path, and Trello may echo it in error responses.
+func (c *Client) redact(s string) string {
+	return redactToken(s, c.Token)
+}
 
-	req, err := http.NewRequest("DELETE", urlWithParams, nil)
-	if err != nil {
-		return fmt.Errorf("Invalid DELETE request %s: %w", url, err)
+func redactToken(s, token string) string {
+	if token == "" {
+		return s
 	}
-
-	return c.do(req, url, target)
+	return strings.ReplaceAll(s, token, "[REDACTED]")
 }
 
 func (c *Client) log(format string, args ...interface{}) {
+	if c.Logger == nil && c.StructuredLogger == nil {
+		return
+	}
+	msg := c.redact(fmt.Sprintf(format, args...))
 	if c.Logger != nil {
-		c.Logger.Debugf(format, args...)
+		c.Logger.Debugf("%s", msg)
+	}
+	if c.StructuredLogger != nil {
+		c.StructuredLogger.Debug(msg)
 	}
 }
 
-func (c *Client) do(req *http.Request, url string, target interface{}) error {
+// logRequest writes a structured record of a completed API call to the
+// StructuredLogger. Failed calls are logged as warnings.
+func (c *Client) logRequest(r *Request, resp *Response, err error, duration time.Duration) {
+	if c.StructuredLogger == nil {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.String("method", r.Method),
+		slog.String("path", r.RedactedPath()),
+		slog.Duration("duration", duration),
+		slog.Int("attempt", max(r.Attempt, 1)),
+	}
+	level := slog.LevelDebug
+	if resp != nil {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+		if remaining, ok := resp.RateLimitRemaining(); ok {
+			attrs = append(attrs, slog.Int("rate_limit_remaining", remaining))
+		}
+	}
+	if err != nil {
+		level = slog.LevelWarn
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	c.StructuredLogger.LogAttrs(r.Context, level, "trello request", attrs...)
+}
+
+func (c *Client) do(req *http.Request, endpoint string) (*Response, error) {
+	endpoint = c.redact(endpoint)
 	resp, err := c.Client.Do(req)
 	if err != nil {
-		return fmt.Errorf("HTTP request failure on %s: %w", url, err)
+		// The error carries the full request URL, including the Token
+		// when it's sent as a URL parameter.
+		var urlErr *neturl.Error
+		if errors.As(err, &urlErr) {
+			urlErr.URL = endpoint
+		}
+		return nil, fmt.Errorf("HTTP request failure on %s: %w", endpoint, err)
 	}
 	defer resp.Body.Close()
+
+	response := &Response{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+	}
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		return makeHTTPClientError(url, resp)
+		return response, makeHTTPClientError(endpoint, resp, c.Token)
 	}
 
-	b, err := io.ReadAll(resp.Body)
+	response.Body, err = io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("HTTP Read error on response for %s: %w", url, err)
+		return response, fmt.Errorf("HTTP Read error on response for %s: %w", endpoint, err)
 	}
-	err = json.Unmarshal(b, target)
-	if err != nil {
-		return fmt.Errorf("JSON decode failed on %s:\n%s\n%w", url, string(b), err)
-	}
-	return nil
+	return response, nil
 }
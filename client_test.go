@@ -6,9 +6,19 @@
 package trello
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
 )
 
 func TestGetWithBadURL(t *testing.T) {
@@ -58,6 +68,237 @@ func TestWithContext(t *testing.T) {
 	}
 }
 
+func TestWithContextAppliesToEveryVerb(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var methods []string
+	c := testClient().WithContext(ctx)
+	c.Client = &http.Client{
+		Transport: &mockTransport{
+			RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+				methods = append(methods, req.Method)
+				if req.Context() != ctx {
+					t.Errorf("%s should be using the client's context", req.Method)
+				}
+				return http.DefaultTransport.RoundTrip(req)
+			},
+		},
+	}
+
+	c.Get("members", nil, nil)
+	c.Put("members", nil, nil)
+	c.Post("members", nil, nil)
+	c.Delete("members", nil, nil)
+	c.PostWithBody("members", nil, nil, "file.txt", strings.NewReader("data"))
+
+	if strings.Join(methods, ",") != "GET,PUT,POST,DELETE,POST" {
+		t.Errorf("Expected each verb to use the mocked transport. Got %v.", methods)
+	}
+}
+
+func TestDoWithCanceledContext(t *testing.T) {
+	c := testClient()
+	var calls int
+	c.Client = &http.Client{
+		Transport: &mockTransport{
+			RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+				calls++
+				return http.DefaultTransport.RoundTrip(req)
+			},
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, method := range []string{"GET", "PUT", "POST", "DELETE"} {
+		err := c.Do(ctx, method, "members", Defaults(), nil)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("%s should fail with context.Canceled. Got %v.", method, err)
+		}
+	}
+	if calls != 0 {
+		t.Errorf("No requests should be sent with a canceled context. Got %d.", calls)
+	}
+}
+
+func TestDoCancelAbortsThrottleWait(t *testing.T) {
+	c := NewClient("user", "pass")
+	c.throttle = rate.NewLimiter(rate.Every(time.Hour), 1)
+	c.throttle.Allow() // Exhaust the only token
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	done := make(chan error)
+	go func() {
+		done <- c.Do(ctx, "PUT", "cards/abc", Defaults(), nil)
+	}()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Error("Do() should fail when its context is canceled during the throttle wait.")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Do() should return promptly when its context is canceled.")
+	}
+}
+
+func TestCredentialsInAuthorizationHeader(t *testing.T) {
+	c := testClient()
+	server := NewMockResponder(t, "members", "api-example.json")
+	server.AssertRequest(func(t *testing.T, r *http.Request) {
+		if r.Header.Get("Authorization") != `OAuth oauth_consumer_key="user", oauth_token="pass"` {
+			t.Errorf("Unexpected Authorization header '%s'.", r.Header.Get("Authorization"))
+		}
+		if r.URL.Query().Has("key") || r.URL.Query().Has("token") {
+			t.Errorf("Credentials should not be sent as URL parameters. Got '%s'.", r.URL.RawQuery)
+		}
+	})
+	defer server.Close()
+	c.BaseURL = server.URL()
+
+	if _, err := c.GetMember("4ee7df1be582acdec80000ae", Defaults()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCredentialsInQuery(t *testing.T) {
+	c := testClient()
+	c.CredentialsInQuery = true
+	server := NewMockResponder(t, "members", "api-example.json")
+	server.AssertRequest(func(t *testing.T, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Errorf("Unexpected Authorization header '%s'.", r.Header.Get("Authorization"))
+		}
+		if r.URL.Query().Get("key") != "user" || r.URL.Query().Get("token") != "pass" {
+			t.Errorf("Expected credentials as URL parameters. Got '%s'.", r.URL.RawQuery)
+		}
+	})
+	defer server.Close()
+	c.BaseURL = server.URL()
+
+	if _, err := c.GetMember("4ee7df1be582acdec80000ae", Defaults()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+type recordingLogger struct {
+	lines []string
+}
+
+func (l *recordingLogger) Debugf(format string, args ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprintf(format, args...))
+}
+
+func TestTokenIsRedacted(t *testing.T) {
+	const secret = "s3cr3t-t0k3n"
+
+	echo := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		http.Error(rw, "invalid request "+r.URL.String(), http.StatusBadRequest)
+	}))
+	defer echo.Close()
+
+	for _, inQuery := range []bool{false, true} {
+		logger := &recordingLogger{}
+		c := NewClient("user", secret)
+		c.testMode = true
+		c.CredentialsInQuery = inQuery
+		c.Logger = logger
+
+		var errs []error
+		c.BaseURL = echo.URL
+		errs = append(errs, c.Get("tokens/"+secret+"/webhooks", Defaults(), &[]interface{}{}))
+
+		c.BaseURL = "http://127.0.0.1:1"
+		errs = append(errs, c.Get("tokens/"+secret, Defaults(), &map[string]interface{}{}))
+
+		c.BaseURL = "gopher://test"
+		errs = append(errs, c.Get("tokens/"+secret, Defaults(), &map[string]interface{}{}))
+
+		for _, err := range errs {
+			if err == nil {
+				t.Fatal("Expected an error.")
+			}
+			if strings.Contains(err.Error(), secret) {
+				t.Errorf("Error leaks the token (CredentialsInQuery=%t): %s", inQuery, err)
+			}
+		}
+		for _, line := range logger.lines {
+			if strings.Contains(line, secret) {
+				t.Errorf("Log output leaks the token (CredentialsInQuery=%t): %s", inQuery, line)
+			}
+		}
+	}
+}
+
+func TestStructuredLogger(t *testing.T) {
+	const secret = "s3cr3t-t0k3n"
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("X-Rate-Limit-Api-Token-Remaining", "97")
+		if strings.HasSuffix(r.URL.Path, "/missing") {
+			http.Error(rw, "model not found", http.StatusNotFound)
+			return
+		}
+		rw.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	c := NewClient("user", secret)
+	c.testMode = true
+	c.BaseURL = server.URL
+	c.StructuredLogger = slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	if err := c.Get("tokens/"+secret, Defaults(), &map[string]interface{}{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Get("cards/missing", Defaults(), &map[string]interface{}{}); !IsNotFound(err) {
+		t.Fatalf("Expected a not-found error. Got %v.", err)
+	}
+
+	if strings.Contains(buf.String(), secret) {
+		t.Errorf("Structured log leaks the token: %s", buf.String())
+	}
+	var records []map[string]interface{}
+	decoder := json.NewDecoder(&buf)
+	for decoder.More() {
+		var record map[string]interface{}
+		if err := decoder.Decode(&record); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 log records. Got %d.", len(records))
+	}
+
+	ok := records[0]
+	if ok["level"] != "DEBUG" || ok["method"] != "GET" || ok["path"] != "tokens/[REDACTED]" {
+		t.Errorf("Unexpected record for a successful request: %v", ok)
+	}
+	if ok["status"] != float64(200) || ok["rate_limit_remaining"] != float64(97) {
+		t.Errorf("Expected status and rate limit attributes. Got %v.", ok)
+	}
+	if ok["attempt"] != float64(1) {
+		t.Errorf("Expected the first attempt. Got %v.", ok["attempt"])
+	}
+	if _, found := ok["duration"]; !found {
+		t.Errorf("Expected a duration attribute. Got %v.", ok)
+	}
+
+	failed := records[1]
+	if failed["level"] != "WARN" || failed["status"] != float64(404) || failed["error"] == nil {
+		t.Errorf("Unexpected record for a failed request: %v", failed)
+	}
+}
+
 type mockTransport struct {
 	RoundTripFunc func(*http.Request) (*http.Response, error)
 }
@@ -0,0 +1,172 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package trello
+
+import (
+	"fmt"
+	"time"
+)
+
+// Comment is a view over a commentCard Action which exposes the comment's
+// author, text and edit state, along with the operations Trello allows on
+// comments.
+// https://developer.atlassian.com/cloud/trello/rest/api-group-actions/
+type Comment struct {
+	*Action
+}
+
+// Reaction represents an emoji reaction left by a member on an Action.
+type Reaction struct {
+	client   *Client
+	ID       string        `json:"id"`
+	IDMember string        `json:"idMember"`
+	IDModel  string        `json:"idModel"`
+	IDEmoji  string        `json:"idEmoji"`
+	Member   *Member       `json:"member,omitempty"`
+	Emoji    ReactionEmoji `json:"emoji"`
+}
+
+// ReactionEmoji is a nested resource of Reaction.
+type ReactionEmoji struct {
+	Unified       string `json:"unified"`
+	Native        string `json:"native"`
+	Name          string `json:"name"`
+	SkinVariation string `json:"skinVariation,omitempty"`
+	ShortName     string `json:"shortName"`
+}
+
+// NewComment wraps a commentCard Action as a Comment. It returns nil if the
+// action is not a comment.
+func NewComment(action *Action) *Comment {
+	if action == nil || !action.DidCommentCard() {
+		return nil
+	}
+	return &Comment{Action: action}
+}
+
+// Text returns the current text of the comment.
+func (c *Comment) Text() string {
+	if c.Data == nil {
+		return ""
+	}
+	return c.Data.Text
+}
+
+// Author returns the member who wrote the comment, if Trello included it.
+func (c *Comment) Author() *Member {
+	return c.MemberCreator
+}
+
+// Edited returns true if the comment was changed after it was posted.
+func (c *Comment) Edited() bool {
+	return !c.EditedAt().IsZero()
+}
+
+// EditedAt returns the time of the comment's last edit, or a zero time if
+// it has never been edited.
+func (c *Comment) EditedAt() time.Time {
+	if c.Data == nil {
+		return time.Time{}
+	}
+	return c.Data.DateLastEdited
+}
+
+// Edit replaces the text of the comment.
+func (c *Comment) Edit(text string, extraArgs ...Arguments) error {
+	args := Arguments{
+		"value": text,
+	}
+	args.flatten(extraArgs)
+	path := fmt.Sprintf("actions/%s/text", c.ID)
+	err := c.client.Put(path, args, c.Action)
+	if err != nil {
+		return fmt.Errorf("Error editing comment %s: %w", c.ID, err)
+	}
+	return nil
+}
+
+// Delete deletes the comment.
+func (c *Comment) Delete(extraArgs ...Arguments) error {
+	var response interface{}
+	args := flattenArguments(extraArgs)
+	path := fmt.Sprintf("actions/%s", c.ID)
+	err := c.client.Delete(path, args, &response)
+	if err != nil {
+		return fmt.Errorf("Error deleting comment %s: %w", c.ID, err)
+	}
+	return nil
+}
+
+// GetReactions returns the reactions left on the comment or an error.
+func (c *Comment) GetReactions(extraArgs ...Arguments) (reactions []*Reaction, err error) {
+	args := Arguments{
+		"member": "true",
+		"emoji":  "true",
+	}
+	args.flatten(extraArgs)
+	path := fmt.Sprintf("actions/%s/reactions", c.ID)
+	err = c.client.Get(path, args, &reactions)
+	for i := range reactions {
+		reactions[i].SetClient(c.client)
+	}
+	return
+}
+
+// AddReaction reacts to the comment with the emoji given by its short name
+// (e.g. "thumbsup") and returns the created Reaction or an error.
+func (c *Comment) AddReaction(shortName string, extraArgs ...Arguments) (reaction *Reaction, err error) {
+	args := Arguments{
+		"shortName": shortName,
+	}
+	args.flatten(extraArgs)
+	path := fmt.Sprintf("actions/%s/reactions", c.ID)
+	err = c.client.Post(path, args, &reaction)
+	if err != nil {
+		err = fmt.Errorf("Error reacting to comment %s: %w", c.ID, err)
+	} else if reaction != nil {
+		reaction.SetClient(c.client)
+	}
+	return
+}
+
+// RemoveReaction removes the reaction given by reactionID from the comment.
+func (c *Comment) RemoveReaction(reactionID string, extraArgs ...Arguments) error {
+	var response interface{}
+	args := flattenArguments(extraArgs)
+	path := fmt.Sprintf("actions/%s/reactions/%s", c.ID, reactionID)
+	err := c.client.Delete(path, args, &response)
+	if err != nil {
+		return fmt.Errorf("Error removing reaction %s from comment %s: %w", reactionID, c.ID, err)
+	}
+	return nil
+}
+
+// GetComments returns the comments on the card, newest first, along with
+// their authors.
+func (c *Card) GetComments(extraArgs ...Arguments) (comments []*Comment, err error) {
+	args := Arguments{
+		"filter":        "commentCard",
+		"memberCreator": "true",
+	}
+	args.flatten(extraArgs)
+	actions, err := c.GetActions(args)
+	if err != nil {
+		return nil, err
+	}
+	comments = make([]*Comment, 0, len(actions))
+	for _, action := range actions {
+		if comment := NewComment(action); comment != nil {
+			comments = append(comments, comment)
+		}
+	}
+	return comments, nil
+}
+
+// SetClient can be used to override this Reaction's internal connection to
+// the Trello API. Normally, this is set automatically after API calls.
+func (r *Reaction) SetClient(newClient *Client) {
+	r.client = newClient
+}
@@ -0,0 +1,159 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package trello
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestCardGetComments(t *testing.T) {
+	card := testCard(t)
+
+	server := NewMockResponder(t, "comments", "card-comments.json")
+	server.AssertRequest(func(t *testing.T, r *http.Request) {
+		if r.URL.Query().Get("filter") != "commentCard" {
+			t.Errorf("Expected filter=commentCard. Got '%s'.", r.URL.Query().Get("filter"))
+		}
+	})
+	defer server.Close()
+	card.client.BaseURL = server.URL()
+
+	comments, err := card.GetComments()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(comments) != 2 {
+		t.Fatalf("Expected 2 comments. Got %d.", len(comments))
+	}
+	if comments[0].Text() != "Deploy finished: all green" {
+		t.Errorf("Unexpected comment text '%s'.", comments[0].Text())
+	}
+	if comments[0].Author() == nil || comments[0].Author().Username != "bobtester" {
+		t.Errorf("Expected comment author 'bobtester'. Got %v.", comments[0].Author())
+	}
+	if !comments[0].Edited() {
+		t.Error("Expected the first comment to be edited.")
+	}
+	if comments[1].Edited() {
+		t.Error("Expected the second comment to be unedited.")
+	}
+	if comments[0].client == nil {
+		t.Error("Expected comments to have a client.")
+	}
+}
+
+func TestNewCommentRejectsNonComments(t *testing.T) {
+	if NewComment(&Action{Type: "updateCard"}) != nil {
+		t.Error("NewComment() should return nil for non-comment actions.")
+	}
+	if NewComment(nil) != nil {
+		t.Error("NewComment() should return nil for a nil action.")
+	}
+}
+
+func TestCommentEdit(t *testing.T) {
+	comment := testComment(t)
+
+	server := NewMockResponder(t, "comments", "comment-edited.json")
+	server.AssertRequest(func(t *testing.T, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/actions/5f3e1b2c9a8d7e0012ab0001/text" {
+			t.Errorf("Unexpected request %s %s.", r.Method, r.URL.Path)
+		}
+		if r.URL.Query().Get("value") != "Deploy in progress" {
+			t.Errorf("Unexpected value '%s'.", r.URL.Query().Get("value"))
+		}
+	})
+	defer server.Close()
+	comment.client.BaseURL = server.URL()
+
+	err := comment.Edit("Deploy in progress")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if comment.Text() != "Deploy in progress" {
+		t.Errorf("Expected comment text to be updated. Got '%s'.", comment.Text())
+	}
+	if !comment.Edited() {
+		t.Error("Expected comment to be marked as edited.")
+	}
+}
+
+func TestCommentDelete(t *testing.T) {
+	comment := testComment(t)
+
+	server := NewMockResponder(t, "comments", "deleted.json")
+	server.AssertRequest(func(t *testing.T, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/actions/5f3e1b2c9a8d7e0012ab0001" {
+			t.Errorf("Unexpected request %s %s.", r.Method, r.URL.Path)
+		}
+	})
+	defer server.Close()
+	comment.client.BaseURL = server.URL()
+
+	if err := comment.Delete(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCommentReactions(t *testing.T) {
+	comment := testComment(t)
+
+	server := NewMockResponder(t, "comments", "reaction.json")
+	server.AssertRequest(func(t *testing.T, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/actions/5f3e1b2c9a8d7e0012ab0001/reactions" {
+			t.Errorf("Unexpected request %s %s.", r.Method, r.URL.Path)
+		}
+		if r.URL.Query().Get("shortName") != "thumbsup" {
+			t.Errorf("Expected shortName=thumbsup. Got '%s'.", r.URL.Query().Get("shortName"))
+		}
+	})
+	defer server.Close()
+	comment.client.BaseURL = server.URL()
+
+	reaction, err := comment.AddReaction("thumbsup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reaction.Emoji.Native != "👍" {
+		t.Errorf("Unexpected emoji '%s'.", reaction.Emoji.Native)
+	}
+
+	server = NewMockResponder(t, "comments", "deleted.json")
+	server.AssertRequest(func(t *testing.T, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/actions/5f3e1b2c9a8d7e0012ab0001/reactions/"+reaction.ID {
+			t.Errorf("Unexpected request %s %s.", r.Method, r.URL.Path)
+		}
+	})
+	defer server.Close()
+	comment.client.BaseURL = server.URL()
+
+	if err := comment.RemoveReaction(reaction.ID); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCommentGetReactions(t *testing.T) {
+	comment := testComment(t)
+
+	server := NewMockResponder(t, "comments", "reactions.json")
+	defer server.Close()
+	comment.client.BaseURL = server.URL()
+
+	reactions, err := comment.GetReactions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reactions) != 1 || reactions[0].Member == nil {
+		t.Fatalf("Expected 1 reaction with a member. Got %v.", reactions)
+	}
+}
+
+func testComment(t *testing.T) *Comment {
+	action := &Action{ID: "5f3e1b2c9a8d7e0012ab0001", Type: "commentCard", Data: &ActionData{Text: "Starting deploy"}}
+	action.SetClient(testClient())
+	return NewComment(action)
+}
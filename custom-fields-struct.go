@@ -0,0 +1,321 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package trello
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Custom field types.
+const (
+	CustomFieldTypeText     = "text"
+	CustomFieldTypeNumber   = "number"
+	CustomFieldTypeDate     = "date"
+	CustomFieldTypeCheckbox = "checkbox"
+	CustomFieldTypeList     = "list"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// structCustomField is a struct field tagged with the name of a custom field.
+type structCustomField struct {
+	index     int
+	name      string
+	optional  bool
+	omitEmpty bool
+}
+
+// DecodeCustomFields copies the card's custom field values into the struct
+// pointed to by dst. Struct fields are matched to the board's custom fields
+// by their `trello` tag:
+//
+//	type Story struct {
+//		Points   float64    `trello:"Story Points"`
+//		Priority string     `trello:"Priority"`
+//		Due      *time.Time `trello:"Release Date"`
+//		Blocked  bool       `trello:"Blocked"`
+//		Team     string     `trello:"Team,optional"`
+//	}
+//
+// Number fields decode into any integer or float type, date fields into
+// time.Time, checkbox fields into bool, and text and list fields into
+// string (list fields as the selected option's text). Pointers are left nil
+// when the card has no value. Fields the card has no value for are left
+// as they are.
+//
+// It returns an error if a tagged name isn't one of the board's custom
+// fields, unless the tag has the optional flag, or if a struct field's type
+// doesn't suit the custom field's type. Fetch the card with the
+// customFieldItems argument so its values are present.
+func (c *Card) DecodeCustomFields(boardCustomFields []*CustomField, dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("DecodeCustomFields needs a pointer to a struct, not %T", dst)
+	}
+	v = v.Elem()
+
+	for _, sf := range taggedCustomFields(v.Type()) {
+		field := customFieldByName(boardCustomFields, sf.name)
+		if field == nil {
+			if sf.optional {
+				continue
+			}
+			return fmt.Errorf("custom field '%s' not found on the board", sf.name)
+		}
+		var item *CustomFieldItem
+		for _, i := range c.CustomFieldItems {
+			if i.IDCustomField == field.ID {
+				item = i
+			}
+		}
+		if err := decodeCustomField(field, item, v.Field(sf.index)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EncodeCustomFields sets the card's custom fields from the struct src (or a
+// pointer to it), tagged as for DecodeCustomFields. Zero values clear the
+// custom field, unless the tag has the omitempty flag, in which case they
+// leave it as it is. List fields are set to the option whose text matches
+// the struct field.
+//
+// All fields are checked before any are set. It returns an error if a
+// tagged name isn't one of the board's custom fields (unless the tag has the
+// optional flag), if a struct field's type doesn't suit the custom field's
+// type, or if a list field has no matching option.
+func (c *Card) EncodeCustomFields(boardCustomFields []*CustomField, src interface{}) error {
+	v := reflect.ValueOf(src)
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("EncodeCustomFields needs a struct, not %T", src)
+	}
+
+	type update struct {
+		field    *CustomField
+		value    interface{}
+		optionID string
+	}
+	var updates []update
+	for _, sf := range taggedCustomFields(v.Type()) {
+		field := customFieldByName(boardCustomFields, sf.name)
+		if field == nil {
+			if sf.optional {
+				continue
+			}
+			return fmt.Errorf("custom field '%s' not found on the board", sf.name)
+		}
+		fv := v.Field(sf.index)
+		if sf.omitEmpty && fv.IsZero() {
+			continue
+		}
+		value, err := encodeCustomField(field, fv)
+		if err != nil {
+			return err
+		}
+		u := update{field: field, value: value}
+		if field.Type == CustomFieldTypeList {
+			u.value = nil
+			if text, _ := value.(string); text != "" {
+				option := field.OptionByText(text)
+				if option == nil {
+					return fmt.Errorf("custom field '%s' has no option '%s'", field.Name, text)
+				}
+				u.optionID = option.ID
+			}
+		}
+		updates = append(updates, u)
+	}
+
+	c.customFieldMap = nil
+	for _, u := range updates {
+		var err error
+		if u.field.Type == CustomFieldTypeList {
+			err = c.SetCustomFieldOption(u.field.ID, u.optionID)
+		} else {
+			err = c.SetCustomFieldValue(u.field.ID, u.value)
+		}
+		if err != nil {
+			return fmt.Errorf("setting custom field '%s': %w", u.field.Name, err)
+		}
+	}
+	return nil
+}
+
+func taggedCustomFields(t reflect.Type) (fields []structCustomField) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, ok := f.Tag.Lookup("trello")
+		if !ok || tag == "-" || !f.IsExported() {
+			continue
+		}
+		parts := strings.Split(tag, ",")
+		sf := structCustomField{index: i, name: parts[0]}
+		if sf.name == "" {
+			sf.name = f.Name
+		}
+		for _, option := range parts[1:] {
+			switch option {
+			case "optional":
+				sf.optional = true
+			case "omitempty":
+				sf.omitEmpty = true
+			}
+		}
+		fields = append(fields, sf)
+	}
+	return
+}
+
+func customFieldByName(fields []*CustomField, name string) *CustomField {
+	for _, field := range fields {
+		if field.Name == name {
+			return field
+		}
+	}
+	return nil
+}
+
+func decodeCustomField(field *CustomField, item *CustomFieldItem, dst reflect.Value) error {
+	var value interface{}
+	if item != nil {
+		value = item.Value.Get()
+		if field.Type == CustomFieldTypeList && item.IDValue != "" {
+			option := field.OptionByID(item.IDValue)
+			if option == nil {
+				return fmt.Errorf("custom field '%s' has no option with ID %s", field.Name, item.IDValue)
+			}
+			value = option.Value.Text
+		}
+	}
+
+	target := dst
+	if dst.Kind() == reflect.Pointer {
+		target = reflect.New(dst.Type().Elem()).Elem()
+	}
+	if err := checkCustomFieldType(field, target.Type()); err != nil {
+		return err
+	}
+	if value == nil {
+		if dst.Kind() == reflect.Pointer {
+			dst.SetZero()
+		}
+		return nil
+	}
+
+	mismatch := fmt.Errorf("custom field '%s' has a %T value, which can't be decoded into %s", field.Name, value, target.Type())
+	switch field.Type {
+	case CustomFieldTypeNumber:
+		var n float64
+		switch v := value.(type) {
+		case int:
+			n = float64(v)
+		case int64:
+			n = float64(v)
+		case float64:
+			n = v
+		default:
+			return mismatch
+		}
+		switch target.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if n != float64(int64(n)) || target.OverflowInt(int64(n)) {
+				return fmt.Errorf("custom field '%s' value %v doesn't fit in %s", field.Name, n, target.Type())
+			}
+			target.SetInt(int64(n))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if n < 0 || n != float64(uint64(n)) || target.OverflowUint(uint64(n)) {
+				return fmt.Errorf("custom field '%s' value %v doesn't fit in %s", field.Name, n, target.Type())
+			}
+			target.SetUint(uint64(n))
+		default:
+			target.SetFloat(n)
+		}
+	default:
+		rv := reflect.ValueOf(value)
+		if !rv.Type().ConvertibleTo(target.Type()) {
+			return mismatch
+		}
+		target.Set(rv.Convert(target.Type()))
+	}
+
+	if dst.Kind() == reflect.Pointer {
+		dst.Set(target.Addr())
+	}
+	return nil
+}
+
+func encodeCustomField(field *CustomField, src reflect.Value) (interface{}, error) {
+	if src.Kind() == reflect.Pointer {
+		if err := checkCustomFieldType(field, src.Type().Elem()); err != nil {
+			return nil, err
+		}
+		if src.IsNil() {
+			return nil, nil
+		}
+		src = src.Elem()
+	} else if err := checkCustomFieldType(field, src.Type()); err != nil {
+		return nil, err
+	}
+
+	switch field.Type {
+	case CustomFieldTypeNumber:
+		switch src.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return src.Int(), nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return int64(src.Uint()), nil
+		default:
+			return src.Float(), nil
+		}
+	case CustomFieldTypeDate:
+		t := src.Convert(timeType).Interface().(time.Time)
+		if t.IsZero() {
+			return nil, nil
+		}
+		return t.UTC(), nil
+	case CustomFieldTypeCheckbox:
+		return src.Bool(), nil
+	default:
+		if src.String() == "" {
+			return nil, nil
+		}
+		return src.String(), nil
+	}
+}
+
+// checkCustomFieldType returns an error unless values of the custom field's
+// type can be stored in t.
+func checkCustomFieldType(field *CustomField, t reflect.Type) error {
+	ok := false
+	switch field.Type {
+	case CustomFieldTypeNumber:
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			ok = true
+		}
+	case CustomFieldTypeDate:
+		ok = t.ConvertibleTo(timeType) && t.Kind() == reflect.Struct
+	case CustomFieldTypeCheckbox:
+		ok = t.Kind() == reflect.Bool
+	case CustomFieldTypeText, CustomFieldTypeList:
+		ok = t.Kind() == reflect.String
+	default:
+		return fmt.Errorf("custom field '%s' has unsupported type '%s'", field.Name, field.Type)
+	}
+	if !ok {
+		return fmt.Errorf("custom field '%s' is a %s field and can't be stored in %s", field.Name, field.Type, t)
+	}
+	return nil
+}
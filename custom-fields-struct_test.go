@@ -0,0 +1,180 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package trello
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testStory struct {
+	Points   float64    `trello:"Story Points"`
+	Estimate int        `trello:"Story Points"`
+	Priority string     `trello:"Priority"`
+	Release  *time.Time `trello:"Release Date"`
+	Blocked  bool       `trello:"Blocked"`
+	Owner    string     `trello:"Owner"`
+	Team     string     `trello:"Team,optional"`
+	Notes    string
+}
+
+func testStoryFields() []*CustomField {
+	var fields []*CustomField
+	json.Unmarshal([]byte(`[
+		{"id": "f-points", "name": "Story Points", "type": "number"},
+		{"id": "f-priority", "name": "Priority", "type": "list", "options": [
+			{"id": "o-high", "idCustomField": "f-priority", "value": {"text": "High"}},
+			{"id": "o-low", "idCustomField": "f-priority", "value": {"text": "Low"}}
+		]},
+		{"id": "f-release", "name": "Release Date", "type": "date"},
+		{"id": "f-blocked", "name": "Blocked", "type": "checkbox"},
+		{"id": "f-owner", "name": "Owner", "type": "text"}
+	]`), &fields)
+	return fields
+}
+
+func testStoryCard() *Card {
+	card := &Card{ID: "card1"}
+	json.Unmarshal([]byte(`[
+		{"idCustomField": "f-points", "value": {"number": "8"}},
+		{"idCustomField": "f-priority", "idValue": "o-high"},
+		{"idCustomField": "f-release", "value": {"date": "2021-06-01T17:00:00.000Z"}},
+		{"idCustomField": "f-blocked", "value": {"checked": "true"}}
+	]`), &card.CustomFieldItems)
+	return card
+}
+
+func TestDecodeCustomFields(t *testing.T) {
+	story := testStory{Owner: "unchanged", Notes: "untagged"}
+	if err := testStoryCard().DecodeCustomFields(testStoryFields(), &story); err != nil {
+		t.Fatal(err)
+	}
+	if story.Points != 8 || story.Estimate != 8 {
+		t.Errorf("Expected 8 points. Got %v and %v.", story.Points, story.Estimate)
+	}
+	if story.Priority != "High" {
+		t.Errorf("Expected the High option. Got '%s'.", story.Priority)
+	}
+	if story.Release == nil || !story.Release.Equal(time.Date(2021, 6, 1, 17, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the release date. Got %v.", story.Release)
+	}
+	if !story.Blocked {
+		t.Error("Expected Blocked to be checked.")
+	}
+	if story.Owner != "unchanged" || story.Notes != "untagged" {
+		t.Error("Fields without a value should be left as they are.")
+	}
+}
+
+func TestDecodeCustomFieldsErrors(t *testing.T) {
+	card := testStoryCard()
+	fields := testStoryFields()
+
+	var missing struct {
+		Sprint string `trello:"Sprint"`
+	}
+	if err := card.DecodeCustomFields(fields, &missing); err == nil || !strings.Contains(err.Error(), "Sprint") {
+		t.Errorf("Expected an error for a missing field. Got %v.", err)
+	}
+
+	var mismatch struct {
+		Points string `trello:"Story Points"`
+	}
+	if err := card.DecodeCustomFields(fields, &mismatch); err == nil {
+		t.Error("Expected an error decoding a number into a string.")
+	}
+
+	var date struct {
+		Release string `trello:"Release Date"`
+	}
+	if err := card.DecodeCustomFields(fields, &date); err == nil {
+		t.Error("Expected an error decoding a date into a string.")
+	}
+
+	card.CustomFieldItems[0].Value = NewCustomFieldValue(2.5)
+	var truncated struct {
+		Points int `trello:"Story Points"`
+	}
+	if err := card.DecodeCustomFields(fields, &truncated); err == nil {
+		t.Error("Expected an error decoding 2.5 into an int.")
+	}
+
+	if err := card.DecodeCustomFields(fields, testStory{}); err == nil {
+		t.Error("Expected an error for a non-pointer destination.")
+	}
+}
+
+func TestEncodeCustomFields(t *testing.T) {
+	bodies := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		field := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/cards/card1/customField/"), "/item")
+		body, _ := io.ReadAll(r.Body)
+		bodies[field] = string(body)
+		rw.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c := testClient()
+	c.BaseURL = server.URL
+	card := &Card{ID: "card1"}
+	card.SetClient(c)
+
+	type update struct {
+		Points   int        `trello:"Story Points"`
+		Priority string     `trello:"Priority"`
+		Release  *time.Time `trello:"Release Date"`
+		Blocked  bool       `trello:"Blocked"`
+		Owner    string     `trello:"Owner,omitempty"`
+		Team     string     `trello:"Team,optional"`
+	}
+	err := card.EncodeCustomFields(testStoryFields(), update{Points: 5, Priority: "Low", Blocked: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"f-points":   `{"value":{"number":"5"}}`,
+		"f-priority": `{"idValue":"o-low"}`,
+		"f-release":  `{"value":""}`,
+		"f-blocked":  `{"value":{"checked":"true"}}`,
+	}
+	if len(bodies) != len(expected) {
+		t.Errorf("Expected %d fields to be set. Got %v.", len(expected), bodies)
+	}
+	for field, body := range expected {
+		if bodies[field] != body {
+			t.Errorf("Expected %s for %s. Got %s.", body, field, bodies[field])
+		}
+	}
+}
+
+func TestEncodeCustomFieldsValidatesFirst(t *testing.T) {
+	c := testClient()
+	c.BaseURL = "http://127.0.0.1:1"
+	card := &Card{ID: "card1"}
+	card.SetClient(c)
+
+	type unknownOption struct {
+		Points   int    `trello:"Story Points"`
+		Priority string `trello:"Priority"`
+	}
+	err := card.EncodeCustomFields(testStoryFields(), unknownOption{Points: 1, Priority: "Urgent"})
+	if err == nil || !strings.Contains(err.Error(), "Urgent") {
+		t.Errorf("Expected an error for an unknown option. Got %v.", err)
+	}
+
+	type mismatch struct {
+		Blocked string `trello:"Blocked"`
+	}
+	if err := card.EncodeCustomFields(testStoryFields(), &mismatch{}); err == nil {
+		t.Error("Expected an error encoding a string into a checkbox.")
+	}
+}
@@ -158,3 +158,56 @@ func (b *Board) GetCustomFields(extraArgs ...Arguments) (customFields []*CustomF
 	err = b.client.Get(path, args, &customFields)
 	return
 }
+
+// GetCustomFieldItems returns the receiver card's custom field values.
+func (c *Card) GetCustomFieldItems(extraArgs ...Arguments) (items []*CustomFieldItem, err error) {
+	args := flattenArguments(extraArgs)
+	path := fmt.Sprintf("cards/%s/customFieldItems", c.ID)
+	err = c.client.Get(path, args, &items)
+	return
+}
+
+// SetCustomFieldValue sets the value of the custom field with the given ID
+// on the receiver card. The value may be a string, number, bool or
+// time.Time, matching the field's type. Pass nil to clear the field. Use
+// SetCustomFieldOption for list fields.
+func (c *Card) SetCustomFieldValue(fieldID string, value interface{}) error {
+	body := map[string]interface{}{"value": ""}
+	if value != nil {
+		body["value"] = NewCustomFieldValue(value)
+	}
+	return c.setCustomFieldItem(fieldID, body)
+}
+
+// SetCustomFieldOption selects the option with the given ID in the list
+// custom field with the given ID on the receiver card. Pass an empty
+// optionID to clear the field.
+func (c *Card) SetCustomFieldOption(fieldID, optionID string) error {
+	return c.setCustomFieldItem(fieldID, map[string]interface{}{"idValue": optionID})
+}
+
+func (c *Card) setCustomFieldItem(fieldID string, body map[string]interface{}) error {
+	path := fmt.Sprintf("cards/%s/customField/%s/item", c.ID, fieldID)
+	var item CustomFieldItem
+	return c.client.PutJSON(path, Arguments{}, body, &item)
+}
+
+// OptionByText returns the list field's option with the given text, or nil.
+func (cf *CustomField) OptionByText(text string) *CustomFieldOption {
+	for _, option := range cf.Options {
+		if option.Value.Text == text {
+			return option
+		}
+	}
+	return nil
+}
+
+// OptionByID returns the list field's option with the given ID, or nil.
+func (cf *CustomField) OptionByID(optionID string) *CustomFieldOption {
+	for _, option := range cf.Options {
+		if option.ID == optionID {
+			return option
+		}
+	}
+	return nil
+}
@@ -6,7 +6,11 @@
 package trello
 
 import (
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestGetCustomField(t *testing.T) {
@@ -31,6 +35,70 @@ func TestGetCustomFieldsOnBoard(t *testing.T) {
 
 }
 
+func TestCardSetCustomFieldValue(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/cards/card1/customField/field1/item" {
+			t.Errorf("Unexpected request %s %s.", r.Method, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		rw.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c := testClient()
+	c.BaseURL = server.URL
+	card := &Card{ID: "card1"}
+	card.SetClient(c)
+
+	due := time.Date(2021, 6, 1, 17, 0, 0, 0, time.UTC)
+	for _, value := range []interface{}{"Platform", 3, true, due, nil} {
+		if err := card.SetCustomFieldValue("field1", value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := card.SetCustomFieldOption("field1", "option1"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		`{"value":{"text":"Platform"}}`,
+		`{"value":{"number":"3"}}`,
+		`{"value":{"checked":"true"}}`,
+		`{"value":{"date":"2021-06-01T17:00:00Z"}}`,
+		`{"value":""}`,
+		`{"idValue":"option1"}`,
+	}
+	if len(bodies) != len(expected) {
+		t.Fatalf("Expected %d requests. Got %d.", len(expected), len(bodies))
+	}
+	for i := range expected {
+		if bodies[i] != expected[i] {
+			t.Errorf("Expected %s. Got %s.", expected[i], bodies[i])
+		}
+	}
+}
+
+func TestCustomFieldOptionLookup(t *testing.T) {
+	var customField *CustomField
+	for _, cf := range testBoardCustomFields(t) {
+		if len(cf.Options) > 0 {
+			customField = cf
+		}
+	}
+	if customField == nil {
+		t.Fatal("Expected a list field with options.")
+	}
+	option := customField.Options[len(customField.Options)-1]
+	if customField.OptionByID(option.ID) != option || customField.OptionByText(option.Value.Text) != option {
+		t.Error("Expected to find the option by ID and text.")
+	}
+	if customField.OptionByText("no such option") != nil {
+		t.Error("Expected no option for unknown text.")
+	}
+}
+
 func testBoardCustomFields(t *testing.T) []*CustomField {
 	board := testBoard(t)
 	board.client.BaseURL = mockResponse("boards", "4ed7e27fe6abb2517a21383d", "customFields.json").URL
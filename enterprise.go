@@ -0,0 +1,226 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package trello
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Enterprise represents a Trello Enterprise account, i.e. a collection of
+// organizations and licensed members managed by a set of admins.
+// https://developer.atlassian.com/cloud/trello/rest/api-group-enterprises/
+type Enterprise struct {
+	client           *Client
+	ID               string             `json:"id"`
+	Name             string             `json:"name"`
+	DisplayName      string             `json:"displayName"`
+	LogoHash         string             `json:"logoHash"`
+	LogoURL          string             `json:"logoUrl"`
+	Prefs            EnterprisePrefs    `json:"prefs"`
+	Products         []int              `json:"products"`
+	IDAdmins         []string           `json:"idAdmins"`
+	IDMembers        []string           `json:"idMembers"`
+	IDOrganizations  []string           `json:"idOrganizations"`
+	Licenses         EnterpriseLicenses `json:"licenses"`
+	Domains          []string           `json:"domains"`
+	IsRealEnterprise bool               `json:"isRealEnterprise"`
+}
+
+// EnterprisePrefs is a nested resource of Enterprise.
+type EnterprisePrefs struct {
+	SSOOnly        bool `json:"ssoOnly"`
+	MobileAccess   bool `json:"mobileAccess"`
+	IsPublicOnOrgs bool `json:"isPublicOnOrgs"`
+}
+
+// EnterpriseLicenses is a nested resource of Enterprise which reports how
+// many seats the Enterprise has available and in use.
+type EnterpriseLicenses struct {
+	MaxMembers   *int `json:"maxMembers"`
+	TotalMembers int  `json:"totalMembers"`
+}
+
+// Available returns the number of unused licenses on the Enterprise, or -1
+// if the Enterprise has no fixed seat limit.
+func (l EnterpriseLicenses) Available() int {
+	if l.MaxMembers == nil {
+		return -1
+	}
+	return *l.MaxMembers - l.TotalMembers
+}
+
+// EnterpriseMember is a Member as seen from an Enterprise. In addition to
+// the regular Member attributes it carries the member's admin and
+// deactivation state across Enterprises and when they were last active.
+type EnterpriseMember struct {
+	Member
+	MemberType               string     `json:"memberType"`
+	IDEnterprise             string     `json:"idEnterprise"`
+	IDEnterprisesAdmin       []string   `json:"idEnterprisesAdmin"`
+	IDEnterprisesDeactivated []string   `json:"idEnterprisesDeactivated"`
+	DateLastAccessed         *time.Time `json:"dateLastAccessed"`
+}
+
+// IsAdminOf returns true if the member is an admin of the Enterprise given by enterpriseID.
+func (m *EnterpriseMember) IsAdminOf(enterpriseID string) bool {
+	return slices.Contains(m.IDEnterprisesAdmin, enterpriseID)
+}
+
+// IsDeactivatedIn returns true if the member has been deactivated in the
+// Enterprise given by enterpriseID.
+func (m *EnterpriseMember) IsDeactivatedIn(enterpriseID string) bool {
+	return slices.Contains(m.IDEnterprisesDeactivated, enterpriseID)
+}
+
+// GetEnterprise takes an enterprise id and Arguments and either
+// GETs returns an Enterprise, or an error.
+func (c *Client) GetEnterprise(enterpriseID string, extraArgs ...Arguments) (enterprise *Enterprise, err error) {
+	args := flattenArguments(extraArgs)
+	path := fmt.Sprintf("enterprises/%s", enterpriseID)
+	err = c.Get(path, args, &enterprise)
+	if enterprise != nil {
+		enterprise.SetClient(c)
+	}
+	return
+}
+
+// GetMembers takes Arguments and returns a slice of the Enterprise's members
+// or an error. Trello pages this endpoint; pass Arguments{"startIndex": "..."}
+// and Arguments{"count": "..."} to walk large Enterprises.
+func (e *Enterprise) GetMembers(extraArgs ...Arguments) (members []*EnterpriseMember, err error) {
+	args := flattenArguments(extraArgs)
+	path := fmt.Sprintf("enterprises/%s/members", e.ID)
+	err = e.client.Get(path, args, &members)
+	for i := range members {
+		members[i].SetClient(e.client)
+	}
+	return
+}
+
+// GetMember takes a member id and Arguments and returns the matching
+// EnterpriseMember or an error.
+func (e *Enterprise) GetMember(memberID string, extraArgs ...Arguments) (member *EnterpriseMember, err error) {
+	args := flattenArguments(extraArgs)
+	path := fmt.Sprintf("enterprises/%s/members/%s", e.ID, memberID)
+	err = e.client.Get(path, args, &member)
+	if member != nil {
+		member.SetClient(e.client)
+	}
+	return
+}
+
+// GetAdmins takes Arguments and returns a slice of the Enterprise's admins or an error.
+func (e *Enterprise) GetAdmins(extraArgs ...Arguments) (admins []*Member, err error) {
+	args := flattenArguments(extraArgs)
+	path := fmt.Sprintf("enterprises/%s/admins", e.ID)
+	err = e.client.Get(path, args, &admins)
+	for i := range admins {
+		admins[i].SetClient(e.client)
+	}
+	return
+}
+
+// GetOrganizations takes Arguments and returns a slice of the organizations
+// managed by the Enterprise or an error.
+func (e *Enterprise) GetOrganizations(extraArgs ...Arguments) (organizations []*Organization, err error) {
+	args := flattenArguments(extraArgs)
+	path := fmt.Sprintf("enterprises/%s/organizations", e.ID)
+	err = e.client.Get(path, args, &organizations)
+	for i := range organizations {
+		organizations[i].SetClient(e.client)
+	}
+	return
+}
+
+// ClaimableOrganizations is the response of the claimable organizations
+// endpoint: workspaces whose members belong to the Enterprise, but which
+// the Enterprise does not yet manage.
+type ClaimableOrganizations struct {
+	Organizations  []*Organization `json:"organizations"`
+	ClaimableCount int             `json:"claimableCount"`
+}
+
+// GetClaimableOrganizations takes Arguments and returns the organizations
+// which can be transferred into the Enterprise or an error.
+func (e *Enterprise) GetClaimableOrganizations(extraArgs ...Arguments) (claimable *ClaimableOrganizations, err error) {
+	args := flattenArguments(extraArgs)
+	path := fmt.Sprintf("enterprises/%s/claimableOrganizations", e.ID)
+	err = e.client.Get(path, args, &claimable)
+	if claimable != nil {
+		for i := range claimable.Organizations {
+			claimable.Organizations[i].SetClient(e.client)
+		}
+	}
+	return
+}
+
+// AddOrganization transfers the organization given by orgID into the
+// Enterprise.
+func (e *Enterprise) AddOrganization(orgID string, extraArgs ...Arguments) error {
+	var response interface{}
+	args := Arguments{
+		"idOrganization": orgID,
+	}
+	args.flatten(extraArgs)
+	path := fmt.Sprintf("enterprises/%s/organizations", e.ID)
+	return e.client.Put(path, args, &response)
+}
+
+// RemoveOrganization removes the organization given by orgID from the Enterprise.
+func (e *Enterprise) RemoveOrganization(orgID string, extraArgs ...Arguments) error {
+	var response interface{}
+	args := flattenArguments(extraArgs)
+	path := fmt.Sprintf("enterprises/%s/organizations/%s", e.ID, orgID)
+	return e.client.Delete(path, args, &response)
+}
+
+// AddAdmin makes the member given by memberID an admin of the Enterprise.
+func (e *Enterprise) AddAdmin(memberID string, extraArgs ...Arguments) error {
+	var response interface{}
+	args := flattenArguments(extraArgs)
+	path := fmt.Sprintf("enterprises/%s/admins/%s", e.ID, memberID)
+	return e.client.Put(path, args, &response)
+}
+
+// RemoveAdmin revokes the Enterprise admin rights of the member given by memberID.
+func (e *Enterprise) RemoveAdmin(memberID string, extraArgs ...Arguments) error {
+	var response interface{}
+	args := flattenArguments(extraArgs)
+	path := fmt.Sprintf("enterprises/%s/admins/%s", e.ID, memberID)
+	return e.client.Delete(path, args, &response)
+}
+
+// DeactivateMember deactivates the member given by memberID, freeing up the
+// license they were using. Returns the updated EnterpriseMember or an error.
+func (e *Enterprise) DeactivateMember(memberID string, extraArgs ...Arguments) (member *EnterpriseMember, err error) {
+	return e.setMemberDeactivated(memberID, true, extraArgs)
+}
+
+// ReactivateMember reverses DeactivateMember for the member given by memberID.
+func (e *Enterprise) ReactivateMember(memberID string, extraArgs ...Arguments) (member *EnterpriseMember, err error) {
+	return e.setMemberDeactivated(memberID, false, extraArgs)
+}
+
+func (e *Enterprise) setMemberDeactivated(memberID string, deactivated bool, extraArgs []Arguments) (member *EnterpriseMember, err error) {
+	args := Arguments{
+		"value": fmt.Sprintf("%t", deactivated),
+	}
+	args.flatten(extraArgs)
+	path := fmt.Sprintf("enterprises/%s/members/%s/deactivated", e.ID, memberID)
+	err = e.client.Put(path, args, &member)
+	if member != nil {
+		member.SetClient(e.client)
+	}
+	return
+}
+
+// SetClient can be used to override this Enterprise's internal connection
+// to the Trello API. Normally, this is set automatically after API calls.
+func (e *Enterprise) SetClient(newClient *Client) {
+	e.client = newClient
+}
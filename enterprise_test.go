@@ -0,0 +1,212 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package trello
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetEnterprise(t *testing.T) {
+	enterprise := testEnterprise(t)
+	if enterprise.DisplayName != "Acme Corp" {
+		t.Errorf("Expected name 'Acme Corp'. Got '%s'.", enterprise.DisplayName)
+	}
+	if enterprise.Licenses.TotalMembers != 3 {
+		t.Errorf("Expected 3 licensed members. Got %d.", enterprise.Licenses.TotalMembers)
+	}
+	if enterprise.Licenses.Available() != 47 {
+		t.Errorf("Expected 47 available licenses. Got %d.", enterprise.Licenses.Available())
+	}
+	if !enterprise.Prefs.SSOOnly {
+		t.Error("Expected Prefs.SSOOnly to be true.")
+	}
+}
+
+func TestEnterpriseLicensesAvailableWithoutLimit(t *testing.T) {
+	l := EnterpriseLicenses{TotalMembers: 10}
+	if l.Available() != -1 {
+		t.Errorf("Expected -1 for an unlimited Enterprise. Got %d.", l.Available())
+	}
+}
+
+func TestEnterpriseGetMembers(t *testing.T) {
+	enterprise := testEnterprise(t)
+
+	server := NewMockResponder(t, "enterprises", "members.json")
+	server.AssertRequest(func(t *testing.T, r *http.Request) {
+		if r.URL.Path != "/enterprises/5e6a9c3b2f1d4c0012a3b4c5/members" {
+			t.Errorf("Unexpected path '%s'.", r.URL.Path)
+		}
+	})
+	defer server.Close()
+	enterprise.client.BaseURL = server.URL()
+
+	members, err := enterprise.GetMembers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(members) != 3 {
+		t.Fatalf("Expected 3 members. Got %d.", len(members))
+	}
+	if members[0].client == nil {
+		t.Error("Expected members to have a client.")
+	}
+	if !members[0].IsAdminOf(enterprise.ID) {
+		t.Errorf("Expected %s to be an admin.", members[0].Username)
+	}
+	if members[1].IsDeactivatedIn(enterprise.ID) {
+		t.Errorf("Expected %s to be active.", members[1].Username)
+	}
+	if !members[2].IsDeactivatedIn(enterprise.ID) {
+		t.Errorf("Expected %s to be deactivated.", members[2].Username)
+	}
+	if members[2].DateLastAccessed != nil {
+		t.Error("Expected a nil DateLastAccessed.")
+	}
+}
+
+func TestEnterpriseGetOrganizations(t *testing.T) {
+	enterprise := testEnterprise(t)
+
+	server := NewMockResponder(t, "enterprises", "organizations.json")
+	defer server.Close()
+	enterprise.client.BaseURL = server.URL()
+
+	orgs, err := enterprise.GetOrganizations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orgs) != 1 {
+		t.Fatalf("Expected 1 organization. Got %d.", len(orgs))
+	}
+	if orgs[0].IDEnterprise != enterprise.ID {
+		t.Errorf("Expected organization to belong to '%s'. Got '%s'.", enterprise.ID, orgs[0].IDEnterprise)
+	}
+}
+
+func TestEnterpriseGetClaimableOrganizations(t *testing.T) {
+	enterprise := testEnterprise(t)
+
+	server := NewMockResponder(t, "enterprises", "claimable-organizations.json")
+	defer server.Close()
+	enterprise.client.BaseURL = server.URL()
+
+	claimable, err := enterprise.GetClaimableOrganizations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claimable.ClaimableCount != 1 || len(claimable.Organizations) != 1 {
+		t.Fatalf("Expected 1 claimable organization. Got %d.", claimable.ClaimableCount)
+	}
+	if claimable.Organizations[0].client == nil {
+		t.Error("Expected claimable organizations to have a client.")
+	}
+}
+
+func TestEnterpriseDeactivateMember(t *testing.T) {
+	enterprise := testEnterprise(t)
+
+	server := NewMockResponder(t, "enterprises", "member-deactivated.json")
+	server.AssertRequest(func(t *testing.T, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("Expected PUT. Got %s.", r.Method)
+		}
+		if r.URL.Path != "/enterprises/5e6a9c3b2f1d4c0012a3b4c5/members/4ee7df74e582acdec80000b6/deactivated" {
+			t.Errorf("Unexpected path '%s'.", r.URL.Path)
+		}
+		if r.URL.Query().Get("value") != "true" {
+			t.Errorf("Expected value=true. Got '%s'.", r.URL.Query().Get("value"))
+		}
+	})
+	defer server.Close()
+	enterprise.client.BaseURL = server.URL()
+
+	member, err := enterprise.DeactivateMember("4ee7df74e582acdec80000b6")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !member.IsDeactivatedIn(enterprise.ID) {
+		t.Error("Expected member to be deactivated.")
+	}
+}
+
+func TestEnterpriseAdminOperations(t *testing.T) {
+	enterprise := testEnterprise(t)
+
+	var methods []string
+	server := NewMockResponder(t, "enterprises", "empty.json")
+	server.AssertRequest(func(t *testing.T, r *http.Request) {
+		methods = append(methods, r.Method)
+		if r.URL.Path != "/enterprises/5e6a9c3b2f1d4c0012a3b4c5/admins/4ee7df74e582acdec80000b6" {
+			t.Errorf("Unexpected path '%s'.", r.URL.Path)
+		}
+	})
+	defer server.Close()
+	enterprise.client.BaseURL = server.URL()
+
+	if err := enterprise.AddAdmin("4ee7df74e582acdec80000b6"); err != nil {
+		t.Fatal(err)
+	}
+	if err := enterprise.RemoveAdmin("4ee7df74e582acdec80000b6"); err != nil {
+		t.Fatal(err)
+	}
+	if len(methods) != 2 || methods[0] != http.MethodPut || methods[1] != http.MethodDelete {
+		t.Errorf("Expected PUT then DELETE. Got %v.", methods)
+	}
+}
+
+func TestEnterpriseOrganizationOperations(t *testing.T) {
+	enterprise := testEnterprise(t)
+
+	var requests []string
+	server := NewMockResponder(t, "enterprises", "empty.json")
+	server.AssertRequest(func(t *testing.T, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+r.URL.Query().Get("idOrganization"))
+	})
+	defer server.Close()
+	enterprise.client.BaseURL = server.URL()
+
+	if err := enterprise.AddOrganization("5e6a9c3b2f1d4c0012a3b4d6"); err != nil {
+		t.Fatal(err)
+	}
+	if enterprise.ID != "5e6a9c3b2f1d4c0012a3b4c5" {
+		t.Errorf("Expected the enterprise to be left alone. Got ID '%s'.", enterprise.ID)
+	}
+	if err := enterprise.RemoveOrganization("5e6a9c3b2f1d4c0012a3b4d6"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"PUT /enterprises/5e6a9c3b2f1d4c0012a3b4c5/organizations 5e6a9c3b2f1d4c0012a3b4d6",
+		"DELETE /enterprises/5e6a9c3b2f1d4c0012a3b4c5/organizations/5e6a9c3b2f1d4c0012a3b4d6 ",
+	}
+	if strings.Join(requests, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected requests:\n%s\nGot:\n%s", strings.Join(expected, "\n"), strings.Join(requests, "\n"))
+	}
+}
+
+func TestEnterpriseSetClient(t *testing.T) {
+	e := Enterprise{}
+	client := testClient()
+	e.SetClient(client)
+	if e.client == nil {
+		t.Error("Expected non-nil Enterprise.client")
+	}
+}
+
+func testEnterprise(t *testing.T) *Enterprise {
+	client := testClient()
+	server := mockResponse("enterprises", "enterprise.json")
+	t.Cleanup(server.Close)
+	client.BaseURL = server.URL
+	enterprise, err := client.GetEnterprise("5e6a9c3b2f1d4c0012a3b4c5", Defaults())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return enterprise
+}
@@ -23,10 +23,16 @@ type httpClientError struct {
 	code int
 }
 
-func makeHTTPClientError(url string, resp *http.Response) error {
+// makeHTTPClientError builds an error from a failed response. Any secrets
+// given are removed from the response body, which may echo the request.
+func makeHTTPClientError(url string, resp *http.Response, secrets ...string) error {
 
 	body, _ := io.ReadAll(resp.Body)
-	msg := fmt.Sprintf("HTTP request failure on %s:\n%d: %s", url, resp.StatusCode, string(body))
+	bodyText := string(body)
+	for _, secret := range secrets {
+		bodyText = redactToken(bodyText, secret)
+	}
+	msg := fmt.Sprintf("HTTP request failure on %s:\n%d: %s", url, resp.StatusCode, bodyText)
 
 	return &httpClientError{
 		msg:  msg,
@@ -0,0 +1,40 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package trello
+
+// EventSource is how an ActionEvent was received.
+type EventSource string
+
+// Event sources.
+const (
+	EventSourcePoll    EventSource = "poll"
+	EventSourceWebhook EventSource = "webhook"
+)
+
+// ActionEvent is an Action which happened on a watched model. Board.Watch
+// produces them by polling, and webhooks deliver them as part of a
+// WebhookEvent, so code which handles ActionEvents works with either.
+type ActionEvent struct {
+	Action *Action
+
+	// IDModel is the ID of the watched model: the board passed to Watch,
+	// or the model the webhook was registered on.
+	IDModel string
+
+	Source EventSource
+}
+
+// Cursor returns the value to persist after handling the event, so
+// watching can resume after it. See WatchOptions.Since.
+func (e ActionEvent) Cursor() string {
+	if e.Action == nil {
+		return ""
+	}
+	return e.Action.ID
+}
+
+// ActionHandler handles ActionEvents.
+type ActionHandler func(ActionEvent)
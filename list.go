@@ -116,3 +116,29 @@ func (l *List) Archive() error {
 func (l *List) Unarchive() error {
 	return l.Update(Arguments{"closed": "false"})
 }
+
+// MoveAllCards moves all of the list's open cards to the list with the ID
+// listID, which may be on the board with the ID boardID rather than the
+// list's own board.
+//
+// API Docs: https://developers.trello.com/reference/#listsidmoveallcards
+func (l *List) MoveAllCards(boardID, listID string, extraArgs ...Arguments) error {
+	args := Arguments{
+		"idBoard": boardID,
+		"idList":  listID,
+	}
+	args.flatten(extraArgs)
+	path := fmt.Sprintf("lists/%s/moveAllCards", l.ID)
+	var response interface{}
+	return l.client.Post(path, args, &response)
+}
+
+// ArchiveAllCards archives all of the list's open cards.
+//
+// API Docs: https://developers.trello.com/reference/#listsidarchiveallcards
+func (l *List) ArchiveAllCards(extraArgs ...Arguments) error {
+	args := flattenArguments(extraArgs)
+	path := fmt.Sprintf("lists/%s/archiveAllCards", l.ID)
+	var response interface{}
+	return l.client.Post(path, args, &response)
+}
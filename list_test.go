@@ -6,6 +6,10 @@
 package trello
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 )
@@ -201,3 +205,28 @@ func TestListSetClient(t *testing.T) {
 		t.Error("Expected non-nil list.client")
 	}
 }
+
+func TestListMoveAndArchiveAllCards(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		requests = append(requests, fmt.Sprintf("%s %s %s %s", r.Method, r.URL.Path, r.FormValue("idBoard"), r.FormValue("idList")))
+		rw.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	c := testClient()
+	c.BaseURL = server.URL
+	list := &List{ID: "4eea4ff"}
+	list.SetClient(c)
+	if err := list.MoveAllCards("board2", "list2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := list.ArchiveAllCards(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "POST /lists/4eea4ff/moveAllCards board2 list2,POST /lists/4eea4ff/archiveAllCards  "
+	if strings.Join(requests, ",") != expected {
+		t.Errorf("Expected %s. Got %s.", expected, strings.Join(requests, ","))
+	}
+}
@@ -7,6 +7,7 @@ package trello
 
 import (
 	"fmt"
+	"strings"
 )
 
 // Member represents a Trello member.
@@ -17,12 +18,40 @@ type Member struct {
 	Username        string   `json:"username"`
 	FullName        string   `json:"fullName"`
 	Initials        string   `json:"initials"`
+	Bio             string   `json:"bio"`
+	URL             string   `json:"url"`
 	AvatarHash      string   `json:"avatarHash"`
+	AvatarURL       string   `json:"avatarUrl"`
 	Email           string   `json:"email"`
 	IDBoards        []string `json:"idBoards"`
 	IDOrganizations []string `json:"idOrganizations"`
 }
 
+// AvatarSize is the size of a Member's avatar image.
+type AvatarSize string
+
+// Avatar sizes Trello serves, in pixels, plus the image as uploaded.
+const (
+	AvatarSize30       AvatarSize = "30"
+	AvatarSize50       AvatarSize = "50"
+	AvatarSize170      AvatarSize = "170"
+	AvatarSizeOriginal AvatarSize = "original"
+)
+
+// avatarBaseURL is where Trello serves avatars when the member has no
+// avatarUrl, e.g. when it wasn't among the requested fields.
+const avatarBaseURL = "https://trello-members.s3.amazonaws.com"
+
+// BoardStar is a Board the Member has starred.
+// https://developer.atlassian.com/cloud/trello/rest/api-group-members/#api-members-id-boardstars-get
+type BoardStar struct {
+	client   *Client
+	idMember string
+	ID       string  `json:"id"`
+	IDBoard  string  `json:"idBoard"`
+	Pos      float64 `json:"pos"`
+}
+
 // GetMember takes a member id and Arguments and returns a Member or an error.
 func (c *Client) GetMember(memberID string, extraArgs ...Arguments) (member *Member, err error) {
 	args := flattenArguments(extraArgs)
@@ -77,6 +106,87 @@ func (c *Card) GetMembers(extraArgs ...Arguments) (members []*Member, err error)
 	return
 }
 
+// Update PUTs the supplied arguments to the Member and updates it from the
+// response, e.g. Arguments{"fullName": "...", "bio": "...", "initials": "..."}.
+func (m *Member) Update(extraArgs ...Arguments) error {
+	args := flattenArguments(extraArgs)
+	path := fmt.Sprintf("members/%s", m.ID)
+	return m.client.Put(path, args, m)
+}
+
+// AvatarImageURL returns the URL of the Member's avatar in the given size,
+// or an empty string if the Member has no avatar.
+func (m *Member) AvatarImageURL(size AvatarSize) string {
+	if m.AvatarHash == "" {
+		return ""
+	}
+	base := m.AvatarURL
+	if base == "" {
+		base = fmt.Sprintf("%s/%s/%s", avatarBaseURL, m.ID, m.AvatarHash)
+	}
+	return fmt.Sprintf("%s/%s.png", strings.TrimSuffix(base, "/"), size)
+}
+
+// GetBoardStars returns the Member's starred boards.
+func (m *Member) GetBoardStars(extraArgs ...Arguments) (stars []*BoardStar, err error) {
+	args := flattenArguments(extraArgs)
+	path := fmt.Sprintf("members/%s/boardStars", m.ID)
+	err = m.client.Get(path, args, &stars)
+	for i := range stars {
+		stars[i].setMember(m.client, m.ID)
+	}
+	return
+}
+
+// StarBoard stars the board with the given ID for the Member. Pass a "pos"
+// argument ("top", "bottom" or a number) to place it among the other stars.
+func (m *Member) StarBoard(boardID string, extraArgs ...Arguments) (star *BoardStar, err error) {
+	args := Arguments{"idBoard": boardID, "pos": "bottom"}
+	args.flatten(extraArgs)
+	path := fmt.Sprintf("members/%s/boardStars", m.ID)
+	err = m.client.Post(path, args, &star)
+	if star != nil {
+		star.setMember(m.client, m.ID)
+	}
+	return
+}
+
+// UnstarBoard removes the Member's star from the board with the given ID,
+// if it's starred.
+func (m *Member) UnstarBoard(boardID string) error {
+	stars, err := m.GetBoardStars()
+	if err != nil {
+		return err
+	}
+	for _, star := range stars {
+		if star.IDBoard == boardID {
+			if err := star.Delete(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// SetPos moves the BoardStar among the Member's other stars. Pass "top",
+// "bottom" or a number.
+func (s *BoardStar) SetPos(pos string) error {
+	path := fmt.Sprintf("members/%s/boardStars/%s", s.idMember, s.ID)
+	return s.client.Put(path, Arguments{"pos": pos}, s)
+}
+
+// Delete removes the BoardStar.
+func (s *BoardStar) Delete() error {
+	path := fmt.Sprintf("members/%s/boardStars/%s", s.idMember, s.ID)
+	var result interface{}
+	return s.client.Delete(path, Arguments{}, &result)
+}
+
+func (s *BoardStar) setMember(client *Client, memberID string) {
+	s.client = client
+	s.idMember = memberID
+}
+
 // SetClient can be used to override this Member's internal connection to the
 // Trello API. Normally, this is set automatically after API calls.
 func (m *Member) SetClient(newClient *Client) {
@@ -6,6 +6,7 @@
 package trello
 
 import (
+	"net/http"
 	"testing"
 )
 
@@ -48,6 +49,217 @@ func TestGetMembersOnCard(t *testing.T) {
 	}
 }
 
+func testMember(c *Client) *Member {
+	m := &Member{ID: "4ee7df1be582acdec80000ae"}
+	m.SetClient(c)
+	return m
+}
+
+func TestMemberGetCards(t *testing.T) {
+	c := testClient()
+	server := NewMockResponder(t, "members", "cards.json")
+	server.AssertRequest(func(t *testing.T, r *http.Request) {
+		if r.URL.Path != "/members/4ee7df1be582acdec80000ae/cards" || r.URL.Query().Get("filter") != "open" {
+			t.Errorf("Unexpected request %s?%s.", r.URL.Path, r.URL.RawQuery)
+		}
+	})
+	defer server.Close()
+	c.BaseURL = server.URL()
+
+	cards, err := testMember(c).GetCards(Arguments{"filter": "open"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cards) != 2 || cards[0].IDBoard == cards[1].IDBoard {
+		t.Fatalf("Expected 2 cards on different boards. Got %d.", len(cards))
+	}
+	if cards[1].client != c {
+		t.Error("Expected the client to be set on the cards.")
+	}
+}
+
+func TestGetMyCards(t *testing.T) {
+	c := testClient()
+	server := NewMockResponder(t, "members", "cards.json")
+	server.AssertRequest(func(t *testing.T, r *http.Request) {
+		if r.URL.Path != "/members/me/cards" {
+			t.Errorf("Unexpected path %s.", r.URL.Path)
+		}
+	})
+	defer server.Close()
+	c.BaseURL = server.URL()
+
+	cards, err := c.GetMyCards()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cards) != 2 {
+		t.Errorf("Expected 2 cards. Got %d.", len(cards))
+	}
+}
+
+func TestMemberGetActions(t *testing.T) {
+	c := testClient()
+	server := NewMockResponder(t, "actions", "board-actions-api-example.json")
+	server.AssertRequest(func(t *testing.T, r *http.Request) {
+		if r.URL.Path != "/members/4ee7df1be582acdec80000ae/actions" {
+			t.Errorf("Unexpected path %s.", r.URL.Path)
+		}
+	})
+	defer server.Close()
+	c.BaseURL = server.URL()
+
+	actions, err := testMember(c).GetActions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(actions) != 4 {
+		t.Errorf("Expected 4 actions. Got %d.", len(actions))
+	}
+}
+
+func TestMemberGetOrganizations(t *testing.T) {
+	c := testClient()
+	server := NewMockResponder(t, "enterprises", "organizations.json")
+	server.AssertRequest(func(t *testing.T, r *http.Request) {
+		if r.URL.Path != "/members/4ee7df1be582acdec80000ae/organizations" {
+			t.Errorf("Unexpected path %s.", r.URL.Path)
+		}
+	})
+	defer server.Close()
+	c.BaseURL = server.URL()
+
+	organizations, err := testMember(c).GetOrganizations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(organizations) != 1 || organizations[0].DisplayName != "Culture Foundry" || organizations[0].client != c {
+		t.Errorf("Unexpected organizations %v.", organizations)
+	}
+}
+
+func TestMemberGetNotifications(t *testing.T) {
+	c := testClient()
+	server := NewMockResponder(t, "notifications", "member-notifications-example.json")
+	server.AssertRequest(func(t *testing.T, r *http.Request) {
+		if r.URL.Path != "/members/4ee7df1be582acdec80000ae/notifications" || r.URL.Query().Get("read_filter") != "unread" {
+			t.Errorf("Unexpected request %s?%s.", r.URL.Path, r.URL.RawQuery)
+		}
+	})
+	defer server.Close()
+	c.BaseURL = server.URL()
+
+	notifications, err := testMember(c).GetNotifications(NotificationFilter{ReadFilter: NotificationsUnread}.ToArguments())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(notifications) != 2 {
+		t.Errorf("Expected 2 notifications. Got %d.", len(notifications))
+	}
+}
+
+func TestMemberUpdate(t *testing.T) {
+	c := testClient()
+	server := NewMockResponder(t, "members", "member-updated.json")
+	server.AssertRequest(func(t *testing.T, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/members/4ee7df1be582acdec80000ae" {
+			t.Errorf("Unexpected request %s %s.", r.Method, r.URL.Path)
+		}
+		if r.FormValue("bio") != "On call for the platform team." {
+			t.Errorf("Expected the bio to be sent. Got '%s'.", r.FormValue("bio"))
+		}
+	})
+	defer server.Close()
+	c.BaseURL = server.URL()
+
+	m := testMember(c)
+	err := m.Update(Arguments{"fullName": "Robert Tester", "initials": "RT", "bio": "On call for the platform team."})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.FullName != "Robert Tester" || m.Initials != "RT" || m.client != c {
+		t.Errorf("Expected the member to be updated. Got %+v.", m)
+	}
+}
+
+func TestMemberAvatarImageURL(t *testing.T) {
+	m := &Member{ID: "4ee7df1be582acdec80000ae", AvatarHash: "8e2a7a5417af80339294d528caab1234"}
+	expected := "https://trello-members.s3.amazonaws.com/4ee7df1be582acdec80000ae/8e2a7a5417af80339294d528caab1234/50.png"
+	if url := m.AvatarImageURL(AvatarSize50); url != expected {
+		t.Errorf("Expected %s. Got %s.", expected, url)
+	}
+
+	m.AvatarURL = "https://trello-avatars.s3.amazonaws.com/8e2a7a5417af80339294d528caab1234"
+	expected = "https://trello-avatars.s3.amazonaws.com/8e2a7a5417af80339294d528caab1234/original.png"
+	if url := m.AvatarImageURL(AvatarSizeOriginal); url != expected {
+		t.Errorf("Expected %s. Got %s.", expected, url)
+	}
+
+	if url := (&Member{ID: "4ee7df1be582acdec80000ae"}).AvatarImageURL(AvatarSize30); url != "" {
+		t.Errorf("Expected no URL for a member without an avatar. Got %s.", url)
+	}
+}
+
+func TestMemberBoardStars(t *testing.T) {
+	c := testClient()
+	server := NewMockResponder(t, "members", "board-stars.json")
+	var requests []string
+	server.AssertRequest(func(t *testing.T, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+	})
+	defer server.Close()
+	c.BaseURL = server.URL()
+
+	m := testMember(c)
+	stars, err := m.GetBoardStars()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stars) != 2 || stars[1].Pos != 32768 {
+		t.Fatalf("Unexpected stars %v.", stars)
+	}
+	if err := m.UnstarBoard("4ee7e707e582acdec800051a"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"GET /members/4ee7df1be582acdec80000ae/boardStars",
+		"GET /members/4ee7df1be582acdec80000ae/boardStars",
+		"DELETE /members/4ee7df1be582acdec80000ae/boardStars/60b0c1d2e3f405162738495b",
+	}
+	if len(requests) != len(expected) {
+		t.Fatalf("Expected %v. Got %v.", expected, requests)
+	}
+	for i := range expected {
+		if requests[i] != expected[i] {
+			t.Errorf("Expected %s. Got %s.", expected[i], requests[i])
+		}
+	}
+}
+
+func TestMemberStarBoard(t *testing.T) {
+	c := testClient()
+	server := NewMockResponder(t, "members", "board-star.json")
+	server.AssertRequest(func(t *testing.T, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/members/4ee7df1be582acdec80000ae/boardStars" {
+			t.Errorf("Unexpected request %s %s.", r.Method, r.URL.Path)
+		}
+		if r.FormValue("idBoard") != "5d2ccd3015468d3df508f10d" || r.FormValue("pos") != "top" {
+			t.Errorf("Unexpected arguments %v.", r.Form)
+		}
+	})
+	defer server.Close()
+	c.BaseURL = server.URL()
+
+	star, err := testMember(c).StarBoard("5d2ccd3015468d3df508f10d", Arguments{"pos": "top"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if star.ID != "60b0c1d2e3f405162738495c" || star.idMember != "4ee7df1be582acdec80000ae" || star.client != c {
+		t.Errorf("Unexpected star %+v.", star)
+	}
+}
+
 func TestMemberSetClient(t *testing.T) {
 	m := Member{}
 	client := testClient()
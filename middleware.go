@@ -0,0 +1,102 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package trello
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+)
+
+// Request is an API call as seen by Middleware. Middleware may change any of
+// its fields before passing it on.
+type Request struct {
+	Context context.Context
+	Method  string
+	Path    string
+	Args    Arguments
+
+	// Target is the value the response body will be decoded into.
+	Target interface{}
+
+	// Upload is the file sent with the request, if any.
+	Upload *Upload
+
+	// Body is sent encoded as JSON, if set and there's no Upload.
+	Body interface{}
+
+	// Attempt is which try at the request this is. The client doesn't retry
+	// requests itself, so it's 1 unless retrying Middleware sets it.
+	Attempt int
+
+	token string
+}
+
+// RedactedPath returns the Path with the Client's Token removed. Use it in
+// place of Path when recording requests in logs or telemetry.
+func (r *Request) RedactedPath() string {
+	return redactToken(r.Path, r.token)
+}
+
+// Response is the raw result of an API call as seen by Middleware. Once the
+// chain of Middleware returns, Body is decoded into the Request's Target.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// RateLimitRemaining returns the number of requests the token may still
+// make in the current rate limit window, as reported by Trello.
+func (r *Response) RateLimitRemaining() (int, bool) {
+	if r.Header == nil {
+		return 0, false
+	}
+	remaining, err := strconv.Atoi(r.Header.Get("X-Rate-Limit-Api-Token-Remaining"))
+	return remaining, err == nil
+}
+
+// Handler runs a Request and returns its Response. Non-2xx responses are
+// returned along with an error.
+type Handler func(req *Request) (*Response, error)
+
+// Middleware wraps a Handler to observe, modify or short-circuit requests.
+// To short-circuit, return a Response without calling next; its Body is
+// decoded into the Request's Target as if it came from Trello.
+//
+//	client.Use(func(next trello.Handler) trello.Handler {
+//		return func(req *trello.Request) (*trello.Response, error) {
+//			if dryRun && req.Method != "GET" {
+//				return &trello.Response{StatusCode: 200, Body: []byte("{}")}, nil
+//			}
+//			return next(req)
+//		}
+//	})
+type Middleware func(next Handler) Handler
+
+// Use adds Middleware to the Client. Middleware runs in the order it was
+// added, so the first Middleware sees each Request first and its Response
+// last. Clients created from this one with WithContext() share its
+// Middleware, but Middleware added to them later is not shared back.
+//
+// Middleware sees every API call except Attachment.Download, which streams
+// the file instead of reading it into a Response, so oteltrello doesn't
+// record it either. Downloads are still logged to Logger and
+// StructuredLogger.
+func (c *Client) Use(middleware ...Middleware) {
+	c.middleware = append(slices.Clip(c.middleware), middleware...)
+}
+
+// handler returns the Client's Middleware chain wrapped around the HTTP
+// round trip.
+func (c *Client) handler() Handler {
+	h := Handler(c.roundTrip)
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		h = c.middleware[i](h)
+	}
+	return h
+}
@@ -0,0 +1,161 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package trello
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestMiddlewareObservesRequestAndResponse(t *testing.T) {
+	c := testClient()
+	server := NewMockResponder(t, "members", "api-example.json")
+	defer server.Close()
+	c.BaseURL = server.URL()
+
+	var seen *Request
+	var status int
+	c.Use(func(next Handler) Handler {
+		return func(req *Request) (*Response, error) {
+			seen = req
+			resp, err := next(req)
+			if resp != nil {
+				status = resp.StatusCode
+			}
+			return resp, err
+		}
+	})
+
+	member, err := c.GetMember("4ee7df1be582acdec80000ae", Arguments{"fields": "all"})
+	if err != nil || member == nil {
+		t.Fatal(err)
+	}
+	if seen.Method != "GET" || seen.Path != "members/4ee7df1be582acdec80000ae" || seen.Args["fields"] != "all" {
+		t.Errorf("Unexpected request %s %s %v.", seen.Method, seen.Path, seen.Args)
+	}
+	if _, ok := seen.Target.(**Member); !ok {
+		t.Errorf("Expected the Request's Target to be a **Member. Got %T.", seen.Target)
+	}
+	if status != http.StatusOK {
+		t.Errorf("Expected status 200. Got %d.", status)
+	}
+}
+
+func TestMiddlewareCanModifyRequest(t *testing.T) {
+	c := testClient()
+	server := NewMockResponder(t, "members", "api-example.json")
+	server.AssertRequest(func(t *testing.T, r *http.Request) {
+		if r.URL.Query().Get("fields") != "username" {
+			t.Errorf("Expected the middleware's argument. Got '%s'.", r.URL.RawQuery)
+		}
+	})
+	defer server.Close()
+	c.BaseURL = server.URL()
+
+	c.Use(func(next Handler) Handler {
+		return func(req *Request) (*Response, error) {
+			req.Args["fields"] = "username"
+			return next(req)
+		}
+	})
+
+	args := Arguments{"fields": "all"}
+	if _, err := c.GetMember("4ee7df1be582acdec80000ae", args); err != nil {
+		t.Fatal(err)
+	}
+	if args["fields"] != "all" {
+		t.Error("Middleware should not modify the caller's Arguments.")
+	}
+}
+
+func TestMiddlewareShortCircuit(t *testing.T) {
+	c := testClient()
+	c.BaseURL = "http://127.0.0.1:1"
+	c.Client = &http.Client{
+		Transport: &mockTransport{
+			RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+				t.Errorf("No request should be sent. Got %s %s.", req.Method, req.URL)
+				return nil, errors.New("unexpected request")
+			},
+		},
+	}
+	c.Use(func(next Handler) Handler {
+		return func(req *Request) (*Response, error) {
+			return &Response{StatusCode: http.StatusOK, Body: []byte(`{"id":"abc","name":"Dry run"}`)}, nil
+		}
+	})
+
+	card := &Card{ID: "abc", Name: "Original"}
+	card.SetClient(c)
+	if err := card.Update(Arguments{"name": "Dry run"}); err != nil {
+		t.Fatal(err)
+	}
+	if card.Name != "Dry run" {
+		t.Errorf("Expected the short-circuit Response to be decoded. Got '%s'.", card.Name)
+	}
+}
+
+func TestMiddlewareShortCircuitWithoutResponse(t *testing.T) {
+	c := testClient()
+	c.Use(func(next Handler) Handler {
+		return func(req *Request) (*Response, error) {
+			return nil, nil
+		}
+	})
+
+	card := &Card{ID: "abc"}
+	card.SetClient(c)
+	err := card.Update(Arguments{"name": "Dry run"})
+	if err == nil || !strings.Contains(err.Error(), "middleware returned no response for PUT cards/abc") {
+		t.Errorf("Expected an error for the missing response. Got %v.", err)
+	}
+}
+
+func TestMiddlewareOrderAndErrors(t *testing.T) {
+	c := testClient()
+	server := mockErrorResponse(http.StatusNotFound)
+	defer server.Close()
+	c.BaseURL = server.URL
+
+	var calls []string
+	var observed error
+	c.Use(
+		func(next Handler) Handler {
+			return func(req *Request) (*Response, error) {
+				calls = append(calls, "outer")
+				resp, err := next(req)
+				observed = err
+				return resp, err
+			}
+		},
+		func(next Handler) Handler {
+			return func(req *Request) (*Response, error) {
+				calls = append(calls, "inner")
+				return next(req)
+			}
+		},
+	)
+
+	_, err := c.GetCard("missing")
+	if !IsNotFound(err) || !IsNotFound(observed) {
+		t.Errorf("Expected middleware and caller to see a not-found error. Got %v and %v.", observed, err)
+	}
+	if strings.Join(calls, ",") != "outer,inner" {
+		t.Errorf("Expected middleware to run in the order it was added. Got %v.", calls)
+	}
+}
+
+func TestUseDoesNotAffectParentClient(t *testing.T) {
+	c := testClient()
+	c.Use(func(next Handler) Handler { return next })
+	scoped := c.WithContext(c.ctx)
+	scoped.Use(func(next Handler) Handler { return next })
+	if len(c.middleware) != 1 || len(scoped.middleware) != 2 {
+		t.Errorf("Expected 1 and 2 middleware. Got %d and %d.", len(c.middleware), len(scoped.middleware))
+	}
+}
@@ -123,7 +123,7 @@ func (mr *mockResponder) mockHandler(rw http.ResponseWriter, r *http.Request) {
 	if mr.useDynamicPaths {
 		parts := []string{mr.mockPath}
 		parts = append(parts, strings.Split(strings.TrimPrefix(r.URL.Path, "/"), "/")...)
-		queryStringPart := strings.Replace(r.URL.RawQuery, "key=user&token=pass", "", -1)
+		queryStringPart := withoutCredentials(r.URL.Query())
 		if queryStringPart != "" {
 			parts[len(parts)-1] = fmt.Sprintf("%s-%x", parts[len(parts)-1], md5.Sum([]byte(queryStringPart)))
 		}
@@ -11,6 +11,7 @@ import (
 	"log"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -35,7 +36,7 @@ func mockDynamicPathResponse() *httptest.Server {
 		// Build the path for the dynamic request
 		parts := []string{".", "testdata"}
 		parts = append(parts, strings.Split(strings.TrimPrefix(r.URL.Path, "/"), "/")...)
-		queryStringPart := strings.Replace(r.URL.RawQuery, "key=user&token=pass", "", -1)
+		queryStringPart := withoutCredentials(r.URL.Query())
 		if queryStringPart != "" {
 			parts[len(parts)-1] = fmt.Sprintf("%s-%x", parts[len(parts)-1], md5.Sum([]byte(queryStringPart)))
 		}
@@ -57,6 +58,14 @@ func mockDynamicPathResponse() *httptest.Server {
 	}))
 }
 
+// withoutCredentials encodes the query without the key and token, which
+// clients send as URL parameters when CredentialsInQuery is set.
+func withoutCredentials(query url.Values) string {
+	query.Del("key")
+	query.Del("token")
+	return query.Encode()
+}
+
 func mockErrorResponse(code int) *httptest.Server {
 	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
 		http.Error(rw, "An error occurred", code)
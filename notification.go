@@ -1,9 +1,32 @@
 package trello
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 )
 
+// Notification types.
+const (
+	NotificationAddedToBoard        = "addedToBoard"
+	NotificationAddedToCard         = "addedToCard"
+	NotificationAddedToOrganization = "addedToOrganization"
+	NotificationCardDueSoon         = "cardDueSoon"
+	NotificationChangeCard          = "changeCard"
+	NotificationCommentCard         = "commentCard"
+	NotificationCreatedCard         = "createdCard"
+	NotificationMentionedOnCard     = "mentionedOnCard"
+	NotificationRemovedFromCard     = "removedFromCard"
+)
+
+// Values for NotificationFilter.ReadFilter.
+const (
+	NotificationsAll    = "all"
+	NotificationsRead   = "read"
+	NotificationsUnread = "unread"
+)
+
 // Notification represents a Trello Notification.
 // https://developers.trello.com/reference/#notifications
 type Notification struct {
@@ -15,16 +38,21 @@ type Notification struct {
 	Type            string           `json:"type"`
 	IDMemberCreator string           `json:"idMemberCreator"`
 	Date            time.Time        `json:"date"`
-	DateRead        time.Time        `json:"dataRead"`
+	DateRead        time.Time        `json:"dateRead"`
 	Data            NotificationData `json:"data,omitempty"`
 	MemberCreator   *Member          `json:"memberCreator,omitempty"`
 }
 
 // NotificationData represents the 'notificaiton.data'
 type NotificationData struct {
-	Text  string                 `json:"text"`
-	Card  *NotificationDataCard  `json:"card,omitempty"`
-	Board *NotificationDataBoard `json:"board,omitempty"`
+	Text         string                        `json:"text"`
+	Card         *NotificationDataCard         `json:"card,omitempty"`
+	Board        *NotificationDataBoard        `json:"board,omitempty"`
+	List         *NotificationDataList         `json:"list,omitempty"`
+	ListBefore   *NotificationDataList         `json:"listBefore,omitempty"`
+	ListAfter    *NotificationDataList         `json:"listAfter,omitempty"`
+	Organization *NotificationDataOrganization `json:"organization,omitempty"`
+	Old          *NotificationDataCard         `json:"old,omitempty"`
 }
 
 // NotificationDataBoard represents the 'notification.data.board'
@@ -34,12 +62,178 @@ type NotificationDataBoard struct {
 	Name      string `json:"name"`
 }
 
-// NotificationDataCard represents the 'notification.data.card'
+// NotificationDataCard represents the 'notification.data.card'. On changeCard
+// notifications, 'notification.data.old' holds the changed fields' previous
+// values in the same shape.
 type NotificationDataCard struct {
-	ID        string `json:"id"`
-	IDShort   int    `json:"idShort"`
-	Name      string `json:"name"`
-	ShortLink string `json:"shortLink"`
+	ID        string     `json:"id"`
+	IDShort   int        `json:"idShort"`
+	Name      string     `json:"name"`
+	ShortLink string     `json:"shortLink"`
+	IDList    string     `json:"idList,omitempty"`
+	Desc      string     `json:"desc,omitempty"`
+	Due       *time.Time `json:"due,omitempty"`
+	Closed    bool       `json:"closed,omitempty"`
+}
+
+// NotificationDataList represents the 'notification.data.list', 'listBefore'
+// and 'listAfter'.
+type NotificationDataList struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// NotificationDataOrganization represents the 'notification.data.organization'
+type NotificationDataOrganization struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// MentionedOnCardPayload is the Payload of a mentionedOnCard notification.
+type MentionedOnCardPayload struct {
+	Board *NotificationDataBoard
+	Card  *NotificationDataCard
+	Text  string
+}
+
+// CommentCardPayload is the Payload of a commentCard notification.
+type CommentCardPayload struct {
+	Board *NotificationDataBoard
+	Card  *NotificationDataCard
+	Text  string
+}
+
+// AddedToCardPayload is the Payload of an addedToCard notification.
+type AddedToCardPayload struct {
+	Board *NotificationDataBoard
+	Card  *NotificationDataCard
+}
+
+// RemovedFromCardPayload is the Payload of a removedFromCard notification.
+type RemovedFromCardPayload struct {
+	Board *NotificationDataBoard
+	Card  *NotificationDataCard
+}
+
+// ChangeCardPayload is the Payload of a changeCard notification. Old holds
+// the previous values of the fields which changed. ListBefore and ListAfter
+// are set when the card moved between lists.
+type ChangeCardPayload struct {
+	Board      *NotificationDataBoard
+	Card       *NotificationDataCard
+	Old        *NotificationDataCard
+	ListBefore *NotificationDataList
+	ListAfter  *NotificationDataList
+}
+
+// CardDueSoonPayload is the Payload of a cardDueSoon notification.
+type CardDueSoonPayload struct {
+	Board *NotificationDataBoard
+	Card  *NotificationDataCard
+}
+
+// CreatedCardPayload is the Payload of a createdCard notification.
+type CreatedCardPayload struct {
+	Board *NotificationDataBoard
+	List  *NotificationDataList
+	Card  *NotificationDataCard
+}
+
+// AddedToBoardPayload is the Payload of an addedToBoard notification.
+type AddedToBoardPayload struct {
+	Board *NotificationDataBoard
+}
+
+// AddedToOrganizationPayload is the Payload of an addedToOrganization
+// notification.
+type AddedToOrganizationPayload struct {
+	Organization *NotificationDataOrganization
+}
+
+// Payload returns the Notification's Data as the typed payload for its Type,
+// e.g. a *MentionedOnCardPayload for a mentionedOnCard notification. It
+// returns nil for types without a payload type; use Data for those.
+//
+//	switch p := n.Payload().(type) {
+//	case *trello.MentionedOnCardPayload:
+//		fmt.Printf("Mentioned on %s: %s\n", p.Card.Name, p.Text)
+//	case *trello.ChangeCardPayload:
+//		...
+//	}
+func (n *Notification) Payload() interface{} {
+	d := n.Data
+	switch n.Type {
+	case NotificationMentionedOnCard:
+		return &MentionedOnCardPayload{Board: d.Board, Card: d.Card, Text: d.Text}
+	case NotificationCommentCard:
+		return &CommentCardPayload{Board: d.Board, Card: d.Card, Text: d.Text}
+	case NotificationAddedToCard:
+		return &AddedToCardPayload{Board: d.Board, Card: d.Card}
+	case NotificationRemovedFromCard:
+		return &RemovedFromCardPayload{Board: d.Board, Card: d.Card}
+	case NotificationChangeCard:
+		return &ChangeCardPayload{Board: d.Board, Card: d.Card, Old: d.Old, ListBefore: d.ListBefore, ListAfter: d.ListAfter}
+	case NotificationCardDueSoon:
+		return &CardDueSoonPayload{Board: d.Board, Card: d.Card}
+	case NotificationCreatedCard:
+		return &CreatedCardPayload{Board: d.Board, List: d.List, Card: d.Card}
+	case NotificationAddedToBoard:
+		return &AddedToBoardPayload{Board: d.Board}
+	case NotificationAddedToOrganization:
+		return &AddedToOrganizationPayload{Organization: d.Organization}
+	}
+	return nil
+}
+
+// NotificationFilter selects notifications by type and read state, a page
+// at a time. Zero values leave Trello's defaults in place: all types, all
+// read states, and the first page of 50.
+//
+//	filter := trello.NotificationFilter{
+//		Types:      []string{trello.NotificationMentionedOnCard},
+//		ReadFilter: trello.NotificationsUnread,
+//	}
+//	notifications, err := client.GetMyNotifications(filter.ToArguments())
+type NotificationFilter struct {
+	Types      []string
+	ReadFilter string
+	Limit      int
+	Page       int
+
+	// Before and Since restrict results to notifications created before or
+	// after the notification with the given ID.
+	Before string
+	Since  string
+}
+
+// ToArguments returns the filter as Arguments for GetMyNotifications.
+func (f NotificationFilter) ToArguments() Arguments {
+	args := Arguments{}
+	if len(f.Types) > 0 {
+		args["filter"] = strings.Join(f.Types, ",")
+	}
+	if f.ReadFilter != "" {
+		args["read_filter"] = f.ReadFilter
+	}
+	if f.Limit > 0 {
+		args["limit"] = strconv.Itoa(f.Limit)
+	}
+	if f.Page > 0 {
+		args["page"] = strconv.Itoa(f.Page)
+	}
+	if f.Before != "" {
+		args["before"] = f.Before
+	}
+	if f.Since != "" {
+		args["since"] = f.Since
+	}
+	return args
+}
+
+// NextPage returns a copy of the filter which selects the following page.
+func (f NotificationFilter) NextPage() NotificationFilter {
+	f.Page++
+	return f
 }
 
 // GetMyNotifications returns the notifications of the authenticated user
@@ -53,6 +247,59 @@ func (c *Client) GetMyNotifications(extraArgs ...Arguments) (notifications []*No
 	return
 }
 
+// GetNotifications returns the Member's notifications. Trello only returns
+// notifications for the member authenticating the API call.
+func (m *Member) GetNotifications(extraArgs ...Arguments) (notifications []*Notification, err error) {
+	args := flattenArguments(extraArgs)
+	path := fmt.Sprintf("members/%s/notifications", m.ID)
+	err = m.client.Get(path, args, &notifications)
+	for i := range notifications {
+		notifications[i].SetClient(m.client)
+	}
+	return
+}
+
+// GetAllMyNotifications returns every notification of the authenticated user
+// which matches the filter, requesting one page after another until a short
+// page is returned.
+func (c *Client) GetAllMyNotifications(filter NotificationFilter, extraArgs ...Arguments) (notifications []*Notification, err error) {
+	if filter.Limit <= 0 {
+		filter.Limit = 50
+	}
+	for {
+		var page []*Notification
+		page, err = c.GetMyNotifications(flattenArguments(extraArgs), filter.ToArguments())
+		notifications = append(notifications, page...)
+		if err != nil || len(page) < filter.Limit {
+			return
+		}
+		filter = filter.NextPage()
+	}
+}
+
+// MarkAllNotificationsRead marks all of the authenticated user's
+// notifications as read.
+func (c *Client) MarkAllNotificationsRead(extraArgs ...Arguments) error {
+	args := flattenArguments(extraArgs)
+	var result interface{}
+	return c.Post("notifications/all/read", args, &result)
+}
+
+// MarkRead marks the Notification as read.
+func (n *Notification) MarkRead() error {
+	return n.setUnread(false)
+}
+
+// MarkUnread marks the Notification as unread.
+func (n *Notification) MarkUnread() error {
+	return n.setUnread(true)
+}
+
+func (n *Notification) setUnread(unread bool) error {
+	path := fmt.Sprintf("notifications/%s/unread", n.ID)
+	return n.client.Put(path, Arguments{"value": strconv.FormatBool(unread)}, n)
+}
+
 // SetClient can be used to override this Notification's internal connection to
 // the Trello API. Normally, this is set automatically after API calls.
 func (n *Notification) SetClient(newClient *Client) {
@@ -1,6 +1,13 @@
 package trello
 
-import "testing"
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
 
 func TestGetMyNotifications(t *testing.T) {
 	c := testClient()
@@ -24,6 +31,159 @@ func TestGetMyNotifications(t *testing.T) {
 	}
 }
 
+func TestNotificationPayload(t *testing.T) {
+	c := testClient()
+	c.BaseURL = mockResponse("notifications", "typed-notifications.json").URL
+	notifications, err := c.GetMyNotifications()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(notifications) != 4 {
+		t.Fatalf("Expected 4 notifications. Got %d.", len(notifications))
+	}
+
+	mention, ok := notifications[0].Payload().(*MentionedOnCardPayload)
+	if !ok {
+		t.Fatalf("Expected a *MentionedOnCardPayload. Got %T.", notifications[0].Payload())
+	}
+	if mention.Card.Name != "Database failover" || mention.Board.Name != "Ops" || mention.Text != "@oncall can you take a look?" {
+		t.Errorf("Unexpected mention %+v.", mention)
+	}
+
+	change, ok := notifications[1].Payload().(*ChangeCardPayload)
+	if !ok {
+		t.Fatalf("Expected a *ChangeCardPayload. Got %T.", notifications[1].Payload())
+	}
+	if change.ListBefore.Name != "Triage" || change.ListAfter.Name != "In Progress" || change.Old.IDList != "5c7008a0b546edbf9a00" {
+		t.Errorf("Unexpected change %+v.", change)
+	}
+	if notifications[1].DateRead.IsZero() {
+		t.Error("Expected DateRead to be decoded.")
+	}
+
+	org, ok := notifications[2].Payload().(*AddedToOrganizationPayload)
+	if !ok || org.Organization.Name != "Platform Team" {
+		t.Errorf("Expected an *AddedToOrganizationPayload for Platform Team. Got %#v.", notifications[2].Payload())
+	}
+
+	if p := notifications[3].Payload(); p != nil {
+		t.Errorf("Expected no payload for an unknown type. Got %T.", p)
+	}
+}
+
+func TestNotificationFilterToArguments(t *testing.T) {
+	filter := NotificationFilter{
+		Types:      []string{NotificationMentionedOnCard, NotificationCommentCard},
+		ReadFilter: NotificationsUnread,
+		Limit:      20,
+		Before:     "6a1f0c2e5d4b3a2918070605",
+	}
+	args := filter.NextPage().ToArguments()
+	expected := Arguments{
+		"filter":      "mentionedOnCard,commentCard",
+		"read_filter": "unread",
+		"limit":       "20",
+		"page":        "1",
+		"before":      "6a1f0c2e5d4b3a2918070605",
+	}
+	if len(args) != len(expected) {
+		t.Errorf("Expected %v. Got %v.", expected, args)
+	}
+	for k, v := range expected {
+		if args[k] != v {
+			t.Errorf("Expected %s=%s. Got '%s'.", k, v, args[k])
+		}
+	}
+	if filter.Page != 0 {
+		t.Error("NextPage() should not modify the receiver.")
+	}
+	if len(NotificationFilter{}.ToArguments()) != 0 {
+		t.Error("An empty filter should have no arguments.")
+	}
+}
+
+func TestGetAllMyNotifications(t *testing.T) {
+	const total = 5
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("filter") != NotificationMentionedOnCard || q.Get("read_filter") != NotificationsUnread {
+			t.Errorf("Expected the filter on every page. Got %s.", r.URL.RawQuery)
+		}
+		pages = append(pages, q.Get("page"))
+		page, _ := strconv.Atoi(q.Get("page"))
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		var notifications []*Notification
+		for i := page * limit; i < total && i < (page+1)*limit; i++ {
+			notifications = append(notifications, &Notification{ID: fmt.Sprintf("n%d", i), Type: NotificationMentionedOnCard})
+		}
+		json.NewEncoder(rw).Encode(notifications)
+	}))
+	defer server.Close()
+
+	c := testClient()
+	c.BaseURL = server.URL
+	notifications, err := c.GetAllMyNotifications(NotificationFilter{
+		Types:      []string{NotificationMentionedOnCard},
+		ReadFilter: NotificationsUnread,
+		Limit:      2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(notifications) != total || notifications[4].ID != "n4" || notifications[4].client != c {
+		t.Errorf("Expected %d notifications across pages. Got %d.", total, len(notifications))
+	}
+	if fmt.Sprint(pages) != "[ 1 2]" {
+		t.Errorf("Expected pages 0 to 2 to be requested. Got %v.", pages)
+	}
+}
+
+func TestNotificationMarkRead(t *testing.T) {
+	c := testClient()
+	server := NewMockResponder(t, "notifications", "notification.json")
+	var values []string
+	server.AssertRequest(func(t *testing.T, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/notifications/6a1f0c2e5d4b3a2918070605/unread" {
+			t.Errorf("Unexpected request %s %s.", r.Method, r.URL.Path)
+		}
+		values = append(values, r.FormValue("value"))
+	})
+	defer server.Close()
+	c.BaseURL = server.URL()
+
+	n := &Notification{ID: "6a1f0c2e5d4b3a2918070605", Unread: true}
+	n.SetClient(c)
+	if err := n.MarkRead(); err != nil {
+		t.Fatal(err)
+	}
+	if n.Unread || n.DateRead.IsZero() {
+		t.Error("Expected the notification to be updated from the response.")
+	}
+	if err := n.MarkUnread(); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(values) != "[false true]" {
+		t.Errorf("Expected unread=false then unread=true. Got %v.", values)
+	}
+}
+
+func TestMarkAllNotificationsRead(t *testing.T) {
+	c := testClient()
+	server := NewMockResponder(t, "webhooks", "deleted.json")
+	server.AssertRequest(func(t *testing.T, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/notifications/all/read" {
+			t.Errorf("Unexpected request %s %s.", r.Method, r.URL.Path)
+		}
+	})
+	defer server.Close()
+	c.BaseURL = server.URL()
+
+	if err := c.MarkAllNotificationsRead(); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestNotificationSetClient(t *testing.T) {
 	n := Notification{}
 	client := testClient()
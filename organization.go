@@ -12,15 +12,16 @@ import (
 // Organization represents a Trello organization or team, i.e. a collection of members and boards.
 // https://developers.trello.com/reference/#organizations
 type Organization struct {
-	client      *Client
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	DisplayName string `json:"displayName"`
-	Desc        string `json:"desc"`
-	URL         string `json:"url"`
-	Website     string `json:"website"`
-	Products    []int  `json:"products"`
-	PowerUps    []int  `json:"powerUps"`
+	client       *Client
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	DisplayName  string `json:"displayName"`
+	Desc         string `json:"desc"`
+	URL          string `json:"url"`
+	Website      string `json:"website"`
+	IDEnterprise string `json:"idEnterprise"`
+	Products     []int  `json:"products"`
+	PowerUps     []int  `json:"powerUps"`
 }
 
 // GetOrganization takes an organization id and Arguments and either
@@ -47,6 +48,17 @@ func (c *Client) GetBoardsInOrganization(orgID string, extraArgs ...Arguments) (
 	return
 }
 
+// GetOrganizations returns the Organizations the Member belongs to.
+func (m *Member) GetOrganizations(extraArgs ...Arguments) (organizations []*Organization, err error) {
+	args := flattenArguments(extraArgs)
+	path := fmt.Sprintf("members/%s/organizations", m.ID)
+	err = m.client.Get(path, args, &organizations)
+	for i := range organizations {
+		organizations[i].SetClient(m.client)
+	}
+	return
+}
+
 // SetClient can be used to override this Organization's internal connection
 // to the Trello API. Normally, this is set automatically after API calls.
 func (o *Organization) SetClient(newClient *Client) {
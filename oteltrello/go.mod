@@ -0,0 +1,21 @@
+module github.com/adlio/trello/oteltrello
+
+go 1.21
+
+require (
+	github.com/adlio/trello v0.0.0-00010101000000-000000000000
+	go.opentelemetry.io/otel v1.24.0
+	go.opentelemetry.io/otel/metric v1.24.0
+	go.opentelemetry.io/otel/sdk v1.24.0
+	go.opentelemetry.io/otel/sdk/metric v1.24.0
+	go.opentelemetry.io/otel/trace v1.24.0
+)
+
+require (
+	github.com/go-logr/logr v1.4.1 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	golang.org/x/sys v0.17.0 // indirect
+	golang.org/x/time v0.10.0 // indirect
+)
+
+replace github.com/adlio/trello => ../
@@ -0,0 +1,121 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+// Package oteltrello instruments a trello.Client with OpenTelemetry. It is
+// a separate module so the trello package itself doesn't depend on
+// OpenTelemetry.
+//
+//	client := trello.NewClient(key, token)
+//	client.Use(oteltrello.Middleware())
+//
+// Each API call becomes a client span, and its duration is recorded in the
+// trello.client.request.duration histogram.
+package oteltrello
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/adlio/trello"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope of the spans and metrics.
+const ScopeName = "github.com/adlio/trello/oteltrello"
+
+// Attribute keys specific to Trello.
+const (
+	PathKey               = attribute.Key("trello.path")
+	RateLimitRemainingKey = attribute.Key("trello.rate_limit.remaining")
+)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// Option configures the Middleware.
+type Option func(*config)
+
+// WithTracerProvider sets the TracerProvider spans are created with. It
+// defaults to the global TracerProvider.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = provider
+	}
+}
+
+// WithMeterProvider sets the MeterProvider metrics are recorded with. It
+// defaults to the global MeterProvider.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = provider
+	}
+}
+
+// Middleware returns trello.Middleware which traces each API call and
+// records its duration. Spans carry the method, the path with the token
+// redacted, the response status and the remaining rate limit. Failed calls
+// are recorded as span errors.
+//
+// Add it before other Middleware so its spans cover them.
+func Middleware(opts ...Option) trello.Middleware {
+	cfg := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	tracer := cfg.tracerProvider.Tracer(ScopeName)
+	meter := cfg.meterProvider.Meter(ScopeName)
+	duration, err := meter.Float64Histogram("trello.client.request.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of Trello API requests."))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return func(next trello.Handler) trello.Handler {
+		return func(req *trello.Request) (*trello.Response, error) {
+			ctx, span := tracer.Start(req.Context, fmt.Sprintf("trello %s", req.Method),
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(
+					semconv.HTTPRequestMethodKey.String(req.Method),
+					PathKey.String(req.RedactedPath()),
+				))
+			defer span.End()
+
+			req.Context = ctx
+			start := time.Now()
+			resp, err := next(req)
+			elapsed := time.Since(start)
+
+			attrs := []attribute.KeyValue{semconv.HTTPRequestMethodKey.String(req.Method)}
+			if resp != nil {
+				status := semconv.HTTPResponseStatusCode(resp.StatusCode)
+				span.SetAttributes(status)
+				attrs = append(attrs, status)
+				if remaining, ok := resp.RateLimitRemaining(); ok {
+					span.SetAttributes(RateLimitRemainingKey.Int(remaining))
+				}
+			}
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			if duration != nil {
+				duration.Record(ctx, elapsed.Seconds(), metric.WithAttributes(attrs...))
+			}
+			return resp, err
+		}
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package oteltrello
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/adlio/trello"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const secret = "s3cr3t-t0k3n"
+
+func testSetup(t *testing.T) (*trello.Client, *tracetest.InMemoryExporter, *sdkmetric.ManualReader) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("X-Rate-Limit-Api-Token-Remaining", "42")
+		if strings.HasSuffix(r.URL.Path, "/missing") {
+			http.Error(rw, "model not found", http.StatusNotFound)
+			return
+		}
+		rw.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+
+	exporter := tracetest.NewInMemoryExporter()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	reader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	c := trello.NewClient("user", secret)
+	c.BaseURL = server.URL
+	c.Use(Middleware(WithTracerProvider(tracerProvider), WithMeterProvider(meterProvider)))
+	return c, exporter, reader
+}
+
+func attributeValue(attrs []attribute.KeyValue, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range attrs {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestMiddlewareRecordsSpan(t *testing.T) {
+	c, exporter, _ := testSetup(t)
+
+	if err := c.Get("tokens/"+secret, trello.Defaults(), &map[string]interface{}{}); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span. Got %d.", len(spans))
+	}
+	span := spans[0]
+	if span.Name != "trello GET" || span.SpanKind != trace.SpanKindClient {
+		t.Errorf("Unexpected span %s (%s).", span.Name, span.SpanKind)
+	}
+	if v, _ := attributeValue(span.Attributes, PathKey); v.AsString() != "tokens/[REDACTED]" {
+		t.Errorf("Expected the redacted path. Got '%s'.", v.AsString())
+	}
+	if v, _ := attributeValue(span.Attributes, semconv.HTTPRequestMethodKey); v.AsString() != "GET" {
+		t.Errorf("Expected method GET. Got '%s'.", v.AsString())
+	}
+	if v, _ := attributeValue(span.Attributes, semconv.HTTPResponseStatusCodeKey); v.AsInt64() != 200 {
+		t.Errorf("Expected status 200. Got %d.", v.AsInt64())
+	}
+	if v, _ := attributeValue(span.Attributes, RateLimitRemainingKey); v.AsInt64() != 42 {
+		t.Errorf("Expected 42 requests remaining. Got %d.", v.AsInt64())
+	}
+	if span.Status.Code == codes.Error {
+		t.Errorf("Expected a successful span. Got %v.", span.Status)
+	}
+}
+
+func TestMiddlewareRecordsError(t *testing.T) {
+	c, exporter, _ := testSetup(t)
+
+	if err := c.Get("cards/missing", trello.Defaults(), &map[string]interface{}{}); !trello.IsNotFound(err) {
+		t.Fatalf("Expected a not-found error. Got %v.", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span. Got %d.", len(spans))
+	}
+	span := spans[0]
+	if span.Status.Code != codes.Error {
+		t.Errorf("Expected an error status. Got %v.", span.Status)
+	}
+	if len(span.Events) != 1 || span.Events[0].Name != "exception" {
+		t.Errorf("Expected the error to be recorded. Got %v.", span.Events)
+	}
+	if v, _ := attributeValue(span.Attributes, semconv.HTTPResponseStatusCodeKey); v.AsInt64() != 404 {
+		t.Errorf("Expected status 404. Got %d.", v.AsInt64())
+	}
+}
+
+func TestMiddlewareRecordsDuration(t *testing.T) {
+	c, _, reader := testSetup(t)
+
+	for i := 0; i < 3; i++ {
+		if err := c.Get("boards/abc", trello.Defaults(), &map[string]interface{}{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	if len(rm.ScopeMetrics) != 1 || len(rm.ScopeMetrics[0].Metrics) != 1 {
+		t.Fatalf("Expected a single metric. Got %v.", rm.ScopeMetrics)
+	}
+	m := rm.ScopeMetrics[0].Metrics[0]
+	if m.Name != "trello.client.request.duration" {
+		t.Errorf("Unexpected metric %s.", m.Name)
+	}
+	histogram, ok := m.Data.(metricdata.Histogram[float64])
+	if !ok || len(histogram.DataPoints) != 1 {
+		t.Fatalf("Expected a histogram with one series. Got %#v.", m.Data)
+	}
+	if histogram.DataPoints[0].Count != 3 {
+		t.Errorf("Expected 3 requests. Got %d.", histogram.DataPoints[0].Count)
+	}
+}
+
+func TestMiddlewarePropagatesContext(t *testing.T) {
+	c, exporter, _ := testSetup(t)
+
+	var inner trace.SpanContext
+	c.Use(func(next trello.Handler) trello.Handler {
+		return func(req *trello.Request) (*trello.Response, error) {
+			inner = trace.SpanContextFromContext(req.Context)
+			return next(req)
+		}
+	})
+	if err := c.Get("boards/abc", trello.Defaults(), &map[string]interface{}{}); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 || spans[0].SpanContext.SpanID() != inner.SpanID() {
+		t.Error("Expected later middleware to see the request span in its context.")
+	}
+}
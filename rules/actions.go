@@ -0,0 +1,135 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package rules
+
+import (
+	"fmt"
+
+	"github.com/adlio/trello"
+)
+
+// Action is something a Rule does to a card. Implement it to write actions
+// in Go. Apply isn't called in dry-run mode.
+type Action interface {
+	Apply(board *Board, card *trello.Card) error
+	String() string
+}
+
+type moveToList struct{ name string }
+
+// MoveToList moves the card to the bottom of the list with the given name.
+func MoveToList(name string) Action {
+	return moveToList{name}
+}
+
+func (a moveToList) Apply(board *Board, card *trello.Card) error {
+	list, err := board.List(a.name)
+	if err != nil {
+		return err
+	}
+	if card.IDList == list.ID {
+		return nil
+	}
+	return card.MoveToList(list.ID, trello.Arguments{"pos": "bottom"})
+}
+
+func (a moveToList) String() string {
+	return fmt.Sprintf("move to list '%s'", a.name)
+}
+
+// moveToListID moves the card back to a list from an action's data, which
+// may have been renamed since.
+type moveToListID struct{ list *trello.List }
+
+func (a moveToListID) Apply(board *Board, card *trello.Card) error {
+	return card.MoveToList(a.list.ID)
+}
+
+func (a moveToListID) String() string {
+	return fmt.Sprintf("move back to list '%s'", a.list.Name)
+}
+
+type addLabel struct{ name string }
+
+// AddLabel adds the label with the given name, or of the given color for
+// labels without a name.
+func AddLabel(name string) Action {
+	return addLabel{name}
+}
+
+func (a addLabel) Apply(board *Board, card *trello.Card) error {
+	label, err := board.Label(a.name)
+	if err != nil {
+		return err
+	}
+	if met, _ := HasLabel(a.name).Match(board, card); met {
+		return nil
+	}
+	return card.AddIDLabel(label.ID)
+}
+
+func (a addLabel) String() string {
+	return fmt.Sprintf("add label '%s'", a.name)
+}
+
+type assign struct{ username string }
+
+// Assign adds the board member with the given username to the card.
+func Assign(username string) Action {
+	return assign{username}
+}
+
+func (a assign) Apply(board *Board, card *trello.Card) error {
+	member, err := board.Member(a.username)
+	if err != nil {
+		return err
+	}
+	for _, id := range card.IDMembers {
+		if id == member.ID {
+			return nil
+		}
+	}
+	_, err = card.AddMemberID(member.ID)
+	return err
+}
+
+func (a assign) String() string {
+	return fmt.Sprintf("assign '%s'", a.username)
+}
+
+type comment struct{ text string }
+
+// Comment comments text on the card.
+func Comment(text string) Action {
+	return comment{text}
+}
+
+func (a comment) Apply(board *Board, card *trello.Card) error {
+	_, err := card.AddComment(a.text)
+	return err
+}
+
+func (a comment) String() string {
+	return fmt.Sprintf("comment '%s'", a.text)
+}
+
+type archive struct{}
+
+// Archive archives the card.
+func Archive() Action {
+	return archive{}
+}
+
+func (a archive) Apply(board *Board, card *trello.Card) error {
+	if card.Closed {
+		return nil
+	}
+	return card.Archive()
+}
+
+func (a archive) String() string {
+	return "archive"
+}
@@ -0,0 +1,162 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package rules
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/adlio/trello"
+)
+
+// cardArgs fetches the parts of a card conditions look at.
+var cardArgs = trello.Arguments{"checklists": "all", "customFieldItems": "true"}
+
+// Board is the board an Engine's rules run on. It looks up the board's
+// lists, labels, members and custom fields by name for Conditions and
+// Actions, fetching each once.
+type Board struct {
+	ID string
+
+	board  *trello.Board
+	client *trello.Client
+
+	mu           sync.Mutex
+	lists        []*trello.List
+	labels       []*trello.Label
+	members      []*trello.Member
+	customFields []*trello.CustomField
+}
+
+func newBoard(client *trello.Client, boardID string) *Board {
+	b := &trello.Board{ID: boardID}
+	b.SetClient(client)
+	return &Board{ID: boardID, board: b, client: client}
+}
+
+// Client returns the client the board's API calls are made with.
+func (b *Board) Client() *trello.Client {
+	return b.client
+}
+
+// Refresh forgets the board's lists, labels, members and custom fields, so
+// they're fetched again the next time they're needed.
+func (b *Board) Refresh() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.lists, b.labels, b.members, b.customFields = nil, nil, nil, nil
+}
+
+// Lists returns the board's open lists.
+func (b *Board) Lists() ([]*trello.List, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.lists == nil {
+		lists, err := b.board.GetLists()
+		if err != nil {
+			return nil, err
+		}
+		b.lists = lists
+	}
+	return b.lists, nil
+}
+
+// List returns the open list with the given name.
+func (b *Board) List(name string) (*trello.List, error) {
+	lists, err := b.Lists()
+	if err != nil {
+		return nil, err
+	}
+	for _, list := range lists {
+		if list.Name == name {
+			return list, nil
+		}
+	}
+	return nil, fmt.Errorf("board %s has no list named '%s'", b.ID, name)
+}
+
+// listByID returns the open list with the given ID, or nil.
+func (b *Board) listByID(id string) (*trello.List, error) {
+	lists, err := b.Lists()
+	if err != nil {
+		return nil, err
+	}
+	for _, list := range lists {
+		if list.ID == id {
+			return list, nil
+		}
+	}
+	return nil, nil
+}
+
+// Label returns the label with the given name, or the given color if no
+// label has that name.
+func (b *Board) Label(name string) (*trello.Label, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.labels == nil {
+		labels, err := b.board.GetLabels()
+		if err != nil {
+			return nil, err
+		}
+		b.labels = labels
+	}
+	for _, label := range b.labels {
+		if label.Name == name {
+			return label, nil
+		}
+	}
+	for _, label := range b.labels {
+		if label.Name == "" && label.Color == name {
+			return label, nil
+		}
+	}
+	return nil, fmt.Errorf("board %s has no label named '%s'", b.ID, name)
+}
+
+// Member returns the board member with the given username.
+func (b *Board) Member(username string) (*trello.Member, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.members == nil {
+		members, err := b.board.GetMembers()
+		if err != nil {
+			return nil, err
+		}
+		b.members = members
+	}
+	for _, member := range b.members {
+		if member.Username == username {
+			return member, nil
+		}
+	}
+	return nil, fmt.Errorf("board %s has no member '%s'", b.ID, username)
+}
+
+// CustomFields returns the board's custom fields.
+func (b *Board) CustomFields() ([]*trello.CustomField, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.customFields == nil {
+		fields, err := b.board.GetCustomFields()
+		if err != nil {
+			return nil, err
+		}
+		b.customFields = fields
+	}
+	return b.customFields, nil
+}
+
+// card fetches a card with everything conditions look at.
+func (b *Board) card(id string) (*trello.Card, error) {
+	return b.client.GetCard(id, cardArgs)
+}
+
+// openCards fetches the board's open cards with everything conditions look
+// at.
+func (b *Board) openCards() ([]*trello.Card, error) {
+	return b.board.GetCards(cardArgs, trello.Arguments{"filter": "open"})
+}
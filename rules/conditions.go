@@ -0,0 +1,161 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package rules
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/adlio/trello"
+)
+
+// Condition is something a card must meet for a Rule's actions to be
+// applied to it. Implement it to write conditions in Go.
+type Condition interface {
+	Match(board *Board, card *trello.Card) (bool, error)
+	String() string
+}
+
+type inList struct{ name string }
+
+// InList is met by cards in the list with the given name.
+func InList(name string) Condition {
+	return inList{name}
+}
+
+func (c inList) Match(board *Board, card *trello.Card) (bool, error) {
+	list, err := board.List(c.name)
+	if err != nil {
+		return false, err
+	}
+	return card.IDList == list.ID, nil
+}
+
+func (c inList) String() string {
+	return fmt.Sprintf("in list '%s'", c.name)
+}
+
+type hasLabel struct{ name string }
+
+// HasLabel is met by cards with the label with the given name, or of the
+// given color for labels without a name.
+func HasLabel(name string) Condition {
+	return hasLabel{name}
+}
+
+func (c hasLabel) Match(board *Board, card *trello.Card) (bool, error) {
+	label, err := board.Label(c.name)
+	if err != nil {
+		return false, err
+	}
+	for _, id := range card.IDLabels {
+		if id == label.ID {
+			return true, nil
+		}
+	}
+	for _, l := range card.Labels {
+		if l.ID == label.ID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (c hasLabel) String() string {
+	return fmt.Sprintf("has label '%s'", c.name)
+}
+
+type checklistComplete struct{ name string }
+
+// ChecklistComplete is met by cards whose checklist with the given name has
+// every item checked. With an empty name, it's met by cards which have
+// checklists, all of them complete.
+func ChecklistComplete(name string) Condition {
+	return checklistComplete{name}
+}
+
+func (c checklistComplete) Match(board *Board, card *trello.Card) (bool, error) {
+	found := false
+	for _, checklist := range card.Checklists {
+		if c.name != "" && checklist.Name != c.name {
+			continue
+		}
+		found = true
+		if incompleteItems(card, checklist) > 0 {
+			return false, nil
+		}
+	}
+	return found, nil
+}
+
+func (c checklistComplete) String() string {
+	if c.name == "" {
+		return "checklists complete"
+	}
+	return fmt.Sprintf("checklist '%s' complete", c.name)
+}
+
+// incompleteItems returns the number of unchecked items on one of the
+// card's checklists. Items are checked if their own state is complete, or
+// the card's CheckItemStates says they are.
+func incompleteItems(card *trello.Card, checklist *trello.Checklist) int {
+	complete := map[string]bool{}
+	for _, state := range card.CheckItemStates {
+		complete[state.IDCheckItem] = state.State == "complete"
+	}
+	incomplete := 0
+	for _, item := range checklist.CheckItems {
+		if item.State != "complete" && !complete[item.ID] {
+			incomplete++
+		}
+	}
+	return incomplete
+}
+
+type customFieldIs struct{ name, value string }
+
+// CustomFieldIs is met by cards whose custom field with the given name has
+// the given value. Values are compared as text: option text for list fields,
+// "true" or "false" for checkboxes, and RFC 3339 for dates. An empty value
+// is met by cards without a value.
+func CustomFieldIs(name, value string) Condition {
+	return customFieldIs{name, value}
+}
+
+func (c customFieldIs) Match(board *Board, card *trello.Card) (bool, error) {
+	fields, err := board.CustomFields()
+	if err != nil {
+		return false, err
+	}
+	value, ok := card.CustomFields(fields)[c.name]
+	if !ok {
+		return c.value == "", nil
+	}
+	if t, ok := value.(time.Time); ok {
+		value = t.Format(time.RFC3339)
+	}
+	return fmt.Sprint(value) == c.value, nil
+}
+
+func (c customFieldIs) String() string {
+	return fmt.Sprintf("custom field '%s' is '%s'", c.name, c.value)
+}
+
+type not struct{ condition Condition }
+
+// Not is met by cards which don't meet condition.
+func Not(condition Condition) Condition {
+	return not{condition}
+}
+
+func (c not) Match(board *Board, card *trello.Card) (bool, error) {
+	met, err := c.condition.Match(board, card)
+	return !met, err
+}
+
+func (c not) String() string {
+	return "not " + c.condition.String()
+}
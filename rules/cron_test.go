@@ -0,0 +1,56 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package rules
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduleNext(t *testing.T) {
+	// A Wednesday.
+	from := time.Date(2021, 6, 2, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		expr     string
+		expected time.Time
+	}{
+		{"*/15 * * * *", time.Date(2021, 6, 2, 10, 45, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2021, 6, 2, 11, 0, 0, 0, time.UTC)},
+		{"0 9 * * mon", time.Date(2021, 6, 7, 9, 0, 0, 0, time.UTC)},
+		{"0 9 * * 1-5", time.Date(2021, 6, 3, 9, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 8 1 jan,apr,jul,oct *", time.Date(2021, 7, 1, 8, 0, 0, 0, time.UTC)},
+		{"30 10 * * *", time.Date(2021, 6, 3, 10, 30, 0, 0, time.UTC)},
+		{"0 12 31 * *", time.Date(2021, 7, 31, 12, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2021, 6, 6, 0, 0, 0, 0, time.UTC)},
+		// Either the 15th or a Friday.
+		{"0 0 15 * fri", time.Date(2021, 6, 4, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, test := range tests {
+		s, err := ParseSchedule(test.expr)
+		if err != nil {
+			t.Errorf("%s: %v", test.expr, err)
+			continue
+		}
+		if next := s.Next(from); !next.Equal(test.expected) {
+			t.Errorf("%s: expected %s. Got %s.", test.expr, test.expected, next)
+		}
+	}
+
+	s, _ := ParseSchedule("0 0 30 2 *")
+	if next := s.Next(from); !next.IsZero() {
+		t.Errorf("Expected February 30th never to come. Got %s.", next)
+	}
+}
+
+func TestParseScheduleErrors(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "* * * foo *", "@sometimes"} {
+		if _, err := ParseSchedule(expr); err == nil {
+			t.Errorf("Expected an error parsing '%s'.", expr)
+		}
+	}
+}
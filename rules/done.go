@@ -0,0 +1,154 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package rules
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/adlio/trello"
+)
+
+// DoneGate enforces a definition of done: cards moved into one of its lists
+// without meeting it are moved back to the list they came from, with a
+// comment explaining what's missing. It handles the events for cards
+// entering a list, from webhooks or Board.Watch.
+type DoneGate struct {
+	// Lists are the names of the lists cards must meet the definition of
+	// done to enter.
+	Lists []string
+
+	// RequireChecklists requires every item on the card's checklists to be
+	// checked.
+	RequireChecklists bool
+
+	// RequireMembers requires the card to have a member assigned.
+	RequireMembers bool
+
+	// RequiredCustomFields are the names of custom fields which must have a
+	// value.
+	RequiredCustomFields []string
+
+	// DryRun, Self, LoopWindow and OnOutcome work as they do for an
+	// Engine.
+	DryRun     bool
+	Self       string
+	LoopWindow time.Duration
+	OnOutcome  func(Outcome)
+
+	board *Board
+	loops *loopGuard
+	now   func() time.Time
+}
+
+// NewDoneGate returns a DoneGate for the lists with the given names on the
+// board with the ID boardID. It requires complete checklists and an assigned
+// member; set RequiredCustomFields to require custom fields too.
+func NewDoneGate(client *trello.Client, boardID string, lists ...string) *DoneGate {
+	g := &DoneGate{
+		Lists:             lists,
+		RequireChecklists: true,
+		RequireMembers:    true,
+		board:             newBoard(client, boardID),
+		now:               time.Now,
+	}
+	g.loops = &loopGuard{self: &g.Self, window: &g.LoopWindow, now: func() time.Time { return g.now() }}
+	return g
+}
+
+// Board returns the board the gate runs on.
+func (g *DoneGate) Board() *Board {
+	return g.board
+}
+
+// Check returns the reasons the card doesn't meet the definition of done,
+// or nothing if it does. Fetch the card with the checklists and
+// customFieldItems arguments so they can be checked.
+func (g *DoneGate) Check(card *trello.Card) ([]string, error) {
+	var reasons []string
+	if g.RequireChecklists {
+		for _, checklist := range card.Checklists {
+			if n := incompleteItems(card, checklist); n > 0 {
+				reasons = append(reasons, fmt.Sprintf("%d of %d items on checklist '%s' aren't checked", n, len(checklist.CheckItems), checklist.Name))
+			}
+		}
+	}
+	if len(g.RequiredCustomFields) > 0 {
+		fields, err := g.board.CustomFields()
+		if err != nil {
+			return nil, err
+		}
+		values := card.CustomFields(fields)
+		for _, name := range g.RequiredCustomFields {
+			if value, ok := values[name]; !ok || value == "" {
+				reasons = append(reasons, fmt.Sprintf("custom field '%s' has no value", name))
+			}
+		}
+	}
+	if g.RequireMembers && len(card.IDMembers) == 0 {
+		reasons = append(reasons, "no one is assigned")
+	}
+	return reasons, nil
+}
+
+// HandleEvent moves the event's card back, with a comment, if the event
+// moved it into one of the gate's lists without meeting the definition of
+// done. Cards created in the list can't be moved back, so they only get the
+// comment.
+func (g *DoneGate) HandleEvent(event trello.ActionEvent) ([]Outcome, error) {
+	a := event.Action
+	if a == nil || a.Data == nil || a.Data.Card == nil || !a.DidChangeListForCard() {
+		return nil, nil
+	}
+	entered := trello.ListAfterAction(a)
+	if entered == nil {
+		return nil, nil
+	}
+	list, err := g.board.listByID(entered.ID)
+	if err != nil || list == nil || !g.gates(list.Name) {
+		return nil, err
+	}
+	name := fmt.Sprintf("Definition of done for '%s'", list.Name)
+
+	if loop, err := g.loops.ownAction(g.board, a); err != nil {
+		return nil, err
+	} else if loop && g.loops.actedRecently(name, a.Data.Card.ID) {
+		return nil, nil
+	}
+
+	card, err := g.board.card(a.Data.Card.ID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching card %s: %w", a.Data.Card.ID, err)
+	}
+	if card.IDList != list.ID {
+		// It has already left the list.
+		return nil, nil
+	}
+	reasons, err := g.Check(card)
+	if err != nil || len(reasons) == 0 {
+		return nil, err
+	}
+
+	text := fmt.Sprintf("This card isn't done yet, so it can't go in '%s':\n\n- %s", list.Name, strings.Join(reasons, "\n- "))
+	actions := []Action{Comment(text)}
+	if a.Data.ListBefore != nil {
+		actions = append(actions, moveToListID{a.Data.ListBefore})
+	}
+	if !g.DryRun {
+		g.loops.acted(name, card.ID)
+	}
+	return applyActions(g.board, name, card, actions, g.DryRun, g.OnOutcome)
+}
+
+func (g *DoneGate) gates(listName string) bool {
+	for _, name := range g.Lists {
+		if name == listName {
+			return true
+		}
+	}
+	return false
+}
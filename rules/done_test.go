@@ -0,0 +1,96 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package rules
+
+import (
+	"testing"
+
+	"github.com/adlio/trello"
+)
+
+func TestDoneGate(t *testing.T) {
+	f := newFakeTrello(t)
+	shippedCard(f, "c1", false)
+	done := shippedCard(f, "c2", true)
+	done.IDMembers = []string{"m-alice"}
+
+	g := NewDoneGate(f.client(), "b1", "Shipped")
+	if outcomes, err := g.HandleEvent(moveEvent("c2", "m-alice")); err != nil || len(outcomes) != 0 {
+		t.Errorf("Expected no outcomes for a done card. Got %v, %v.", outcomes, err)
+	}
+
+	event := moveEvent("c1", "m-alice")
+	event.Action.Data.ListBefore.Name = "Doing"
+	outcomes, err := g.HandleEvent(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outcomes) != 2 || outcomes[1].String() != "Definition of done for 'Shipped': move back to list 'Doing' on card 'Card c1'" {
+		t.Fatalf("Unexpected outcomes %v.", outcomes)
+	}
+	if card := f.card("c1"); card.IDList != "l-doing" {
+		t.Errorf("Expected the card to be moved back to Doing. Got %s.", card.IDList)
+	}
+	expected := "This card isn't done yet, so it can't go in 'Shipped':\n\n" +
+		"- 1 of 1 items on checklist 'Release' aren't checked\n" +
+		"- no one is assigned"
+	if comments := f.comments["c1"]; len(comments) != 1 || comments[0] != expected {
+		t.Errorf("Unexpected comments %q.", comments)
+	}
+
+	// Moves by the gate itself are left alone.
+	f.card("c1").IDList = "l-shipped"
+	event.Action.IDMemberCreator = "self"
+	if outcomes, err := g.HandleEvent(event); err != nil || len(outcomes) != 0 {
+		t.Errorf("Expected the gate's own moves to be ignored. Got %v, %v.", outcomes, err)
+	}
+}
+
+func TestDoneGateCheck(t *testing.T) {
+	f := newFakeTrello(t)
+	g := NewDoneGate(f.client(), "b1", "Shipped")
+	g.RequireMembers = false
+	g.RequiredCustomFields = []string{"Priority"}
+
+	card := &trello.Card{
+		Checklists: []*trello.Checklist{{Name: "Release", CheckItems: []trello.CheckItem{
+			{ID: "i1", Name: "Tag"},
+			{ID: "i2", Name: "Announce"},
+		}}},
+		CheckItemStates: []*trello.CheckItemState{{IDCheckItem: "i1", State: "complete"}},
+	}
+	reasons, err := g.Check(card)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reasons) != 2 || reasons[0] != "1 of 2 items on checklist 'Release' aren't checked" || reasons[1] != "custom field 'Priority' has no value" {
+		t.Errorf("Unexpected reasons %q.", reasons)
+	}
+
+	g.RequireChecklists = false
+	card.CustomFieldItems = []*trello.CustomFieldItem{{IDCustomField: "f-priority", IDValue: "o-high"}}
+	if reasons, err := g.Check(card); err != nil || len(reasons) != 0 {
+		t.Errorf("Expected no reasons. Got %q, %v.", reasons, err)
+	}
+}
+
+func TestDoneGateDryRun(t *testing.T) {
+	f := newFakeTrello(t)
+	shippedCard(f, "c1", false)
+
+	g := NewDoneGate(f.client(), "b1", "Shipped")
+	g.DryRun = true
+	outcomes, err := g.HandleEvent(moveEvent("c1", "m-alice"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outcomes) != 2 || !outcomes[0].DryRun {
+		t.Errorf("Unexpected outcomes %v.", outcomes)
+	}
+	if len(f.writes) != 0 {
+		t.Errorf("Expected no changes in dry-run mode. Got %v.", f.writes)
+	}
+}
@@ -0,0 +1,280 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package rules
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/adlio/trello"
+)
+
+// DefaultTickInterval is how often Run calls Tick when it's given an
+// interval of zero or less.
+const DefaultTickInterval = time.Minute
+
+// Outcome is an action a rule applied to a card, or would have applied in
+// dry-run mode.
+type Outcome struct {
+	Rule   string
+	Card   *trello.Card
+	Action string
+	DryRun bool
+	Err    error
+}
+
+func (o Outcome) String() string {
+	prefix := ""
+	if o.DryRun {
+		prefix = "[dry run] "
+	}
+	s := fmt.Sprintf("%s%s: %s on card '%s'", prefix, o.Rule, o.Action, o.Card.Name)
+	if o.Err != nil {
+		s += ": " + o.Err.Error()
+	}
+	return s
+}
+
+// Engine evaluates rules against the events on a board.
+type Engine struct {
+	// DryRun evaluates the rules and reports the Outcomes without applying
+	// any actions.
+	DryRun bool
+
+	// Self is the ID of the member the engine's client acts as. Events
+	// created by Self on a card a rule acted on within LoopWindow don't
+	// trigger that rule again, so its own actions can't re-trigger it. It
+	// defaults to the client's token's member.
+	Self string
+
+	// LoopWindow defaults to DefaultLoopWindow.
+	LoopWindow time.Duration
+
+	// OnOutcome, when set, is called with each Outcome as it happens.
+	OnOutcome func(Outcome)
+
+	// OnError, when set, is called with the errors Run encounters.
+	OnError func(error)
+
+	board *Board
+	rules []*Rule
+	loops *loopGuard
+
+	mu        sync.Mutex
+	now       func() time.Time
+	lastEvery map[string]time.Time
+	dueFired  map[string]time.Time // by rule, card and due date, to the due date
+}
+
+// NewEngine returns an Engine which runs rules on the board with the ID
+// boardID. It returns an error if a rule is invalid or two rules have the
+// same name.
+func NewEngine(client *trello.Client, boardID string, rules ...*Rule) (*Engine, error) {
+	names := map[string]bool{}
+	for _, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return nil, err
+		}
+		if names[rule.Name] {
+			return nil, fmt.Errorf("two rules are named '%s'", rule.Name)
+		}
+		names[rule.Name] = true
+	}
+	e := &Engine{
+		board:     newBoard(client, boardID),
+		rules:     rules,
+		now:       time.Now,
+		lastEvery: map[string]time.Time{},
+		dueFired:  map[string]time.Time{},
+	}
+	e.loops = &loopGuard{self: &e.Self, window: &e.LoopWindow, now: func() time.Time { return e.now() }}
+	return e, nil
+}
+
+// Board returns the board the engine's rules run on.
+func (e *Engine) Board() *Board {
+	return e.board
+}
+
+// HandleEvent evaluates the rules with action triggers against an event
+// from a webhook or Board.Watch.
+func (e *Engine) HandleEvent(event trello.ActionEvent) ([]Outcome, error) {
+	a := event.Action
+	if a == nil || a.Data == nil || a.Data.Card == nil {
+		return nil, nil
+	}
+
+	var triggered []*Rule
+	for _, rule := range e.rules {
+		if rule.Trigger.matchesAction(a) {
+			triggered = append(triggered, rule)
+		}
+	}
+	if len(triggered) == 0 {
+		return nil, nil
+	}
+
+	if loop, err := e.loops.ownAction(e.board, a); err != nil {
+		return nil, err
+	} else if loop {
+		var remaining []*Rule
+		for _, rule := range triggered {
+			if !e.loops.actedRecently(rule.Name, a.Data.Card.ID) {
+				remaining = append(remaining, rule)
+			}
+		}
+		if triggered = remaining; len(triggered) == 0 {
+			return nil, nil
+		}
+	}
+
+	card, err := e.board.card(a.Data.Card.ID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching card %s: %w", a.Data.Card.ID, err)
+	}
+	var outcomes []Outcome
+	for _, rule := range triggered {
+		o, err := e.evaluate(rule, card)
+		outcomes = append(outcomes, o...)
+		if err != nil {
+			return outcomes, err
+		}
+	}
+	return outcomes, nil
+}
+
+// Tick evaluates the rules with Every and DueWithin triggers which are due
+// to fire.
+func (e *Engine) Tick() ([]Outcome, error) {
+	now := e.now()
+	var every, due []*Rule
+	e.mu.Lock()
+	for _, rule := range e.rules {
+		if rule.Trigger.Every > 0 && now.Sub(e.lastEvery[rule.Name]) >= rule.Trigger.Every {
+			every = append(every, rule)
+			e.lastEvery[rule.Name] = now
+		}
+		if rule.Trigger.DueWithin > 0 {
+			due = append(due, rule)
+		}
+	}
+	for key, dueAt := range e.dueFired {
+		if dueAt.Before(now) {
+			delete(e.dueFired, key)
+		}
+	}
+	e.mu.Unlock()
+	if len(every) == 0 && len(due) == 0 {
+		return nil, nil
+	}
+
+	cards, err := e.board.openCards()
+	if err != nil {
+		return nil, fmt.Errorf("fetching the cards on board %s: %w", e.board.ID, err)
+	}
+	var outcomes []Outcome
+	for _, card := range cards {
+		for _, rule := range every {
+			o, err := e.evaluate(rule, card)
+			outcomes = append(outcomes, o...)
+			if err != nil {
+				return outcomes, err
+			}
+		}
+		for _, rule := range due {
+			if card.Due == nil || card.DueComplete || card.Due.Before(now) || card.Due.Sub(now) > rule.Trigger.DueWithin {
+				continue
+			}
+			key := fmt.Sprintf("%s/%s/%s", rule.Name, card.ID, card.Due.Format(time.RFC3339))
+			e.mu.Lock()
+			_, fired := e.dueFired[key]
+			e.dueFired[key] = *card.Due
+			e.mu.Unlock()
+			if fired {
+				continue
+			}
+			o, err := e.evaluate(rule, card)
+			outcomes = append(outcomes, o...)
+			if err != nil {
+				return outcomes, err
+			}
+		}
+	}
+	return outcomes, nil
+}
+
+// Run handles the events from events, and calls Tick every interval, until
+// ctx is done or events is closed. Errors are passed to OnError, and don't
+// stop Run. events may be nil for engines with only scheduled rules. An
+// interval of zero or less means DefaultTickInterval.
+func (e *Engine) Run(ctx context.Context, events <-chan trello.ActionEvent, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultTickInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	e.report(e.Tick())
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			e.report(e.HandleEvent(event))
+		case <-ticker.C:
+			e.report(e.Tick())
+		}
+	}
+}
+
+func (e *Engine) report(_ []Outcome, err error) {
+	if err != nil && e.OnError != nil {
+		e.OnError(err)
+	}
+}
+
+// evaluate applies the rule's actions to card if it meets the rule's
+// conditions.
+func (e *Engine) evaluate(rule *Rule, card *trello.Card) ([]Outcome, error) {
+	for _, condition := range rule.Conditions {
+		met, err := condition.Match(e.board, card)
+		if err != nil {
+			return nil, fmt.Errorf("rule '%s': %s: %w", rule.Name, condition, err)
+		}
+		if !met {
+			return nil, nil
+		}
+	}
+
+	if !e.DryRun {
+		e.loops.acted(rule.Name, card.ID)
+	}
+	return applyActions(e.board, rule.Name, card, rule.Actions, e.DryRun, e.OnOutcome)
+}
+
+// applyActions applies actions to card on behalf of the named automation,
+// or only reports them in dry-run mode. It stops at the first error.
+func applyActions(board *Board, name string, card *trello.Card, actions []Action, dryRun bool, onOutcome func(Outcome)) ([]Outcome, error) {
+	var outcomes []Outcome
+	for _, action := range actions {
+		o := Outcome{Rule: name, Card: card, Action: action.String(), DryRun: dryRun}
+		if !dryRun {
+			o.Err = action.Apply(board, card)
+		}
+		outcomes = append(outcomes, o)
+		if onOutcome != nil {
+			onOutcome(o)
+		}
+		if o.Err != nil {
+			return outcomes, fmt.Errorf("%s: %s on card %s: %w", name, action, card.ID, o.Err)
+		}
+	}
+	return outcomes, nil
+}
@@ -0,0 +1,290 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package rules
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adlio/trello"
+)
+
+func moveEvent(cardID, memberID string) trello.ActionEvent {
+	return trello.ActionEvent{
+		IDModel: "b1",
+		Source:  trello.EventSourceWebhook,
+		Action: &trello.Action{
+			ID:              "a1",
+			Type:            "updateCard",
+			IDMemberCreator: memberID,
+			Data: &trello.ActionData{
+				Card:       &trello.ActionDataCard{ID: cardID},
+				ListBefore: &trello.List{ID: "l-doing"},
+				ListAfter:  &trello.List{ID: "l-shipped"},
+			},
+		},
+	}
+}
+
+func shippedRule() *Rule {
+	return &Rule{
+		Name:       "Archive shipped cards",
+		Trigger:    Trigger{Actions: []string{"updateCard:idList"}},
+		Conditions: []Condition{InList("Shipped"), ChecklistComplete("")},
+		Actions:    []Action{Comment("Shipped!"), AddLabel("green"), Assign("alice"), Archive()},
+	}
+}
+
+func shippedCard(f *fakeTrello, id string, checked bool) *trello.Card {
+	state := "incomplete"
+	if checked {
+		state = "complete"
+	}
+	return f.addCard(&trello.Card{
+		ID:         id,
+		Name:       "Card " + id,
+		IDList:     "l-shipped",
+		Checklists: []*trello.Checklist{{Name: "Release", CheckItems: []trello.CheckItem{{Name: "Tag", State: state}}}},
+	})
+}
+
+func TestEngineHandleEvent(t *testing.T) {
+	f := newFakeTrello(t)
+	shippedCard(f, "c1", true)
+	shippedCard(f, "c2", false)
+	engine, err := NewEngine(f.client(), "b1", shippedRule())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outcomes, err := engine.HandleEvent(moveEvent("c1", "m-alice"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outcomes) != 4 {
+		t.Fatalf("Expected 4 outcomes. Got %v.", outcomes)
+	}
+	if outcomes[3].String() != "Archive shipped cards: archive on card 'Card c1'" {
+		t.Errorf("Unexpected outcome '%s'.", outcomes[3])
+	}
+	card := f.card("c1")
+	if !card.Closed || len(card.IDLabels) != 1 || card.IDLabels[0] != "lb-green" || len(card.IDMembers) != 1 || len(f.comments["c1"]) != 1 {
+		t.Errorf("Expected the actions to be applied. Got %+v and %v.", card, f.comments)
+	}
+
+	outcomes, err = engine.HandleEvent(moveEvent("c2", "m-alice"))
+	if err != nil || len(outcomes) != 0 {
+		t.Errorf("Expected no outcomes for an incomplete checklist. Got %v, %v.", outcomes, err)
+	}
+
+	outcomes, err = engine.HandleEvent(trello.ActionEvent{Action: &trello.Action{Type: "commentCard", Data: &trello.ActionData{Card: &trello.ActionDataCard{ID: "c2"}}}})
+	if err != nil || len(outcomes) != 0 {
+		t.Errorf("Expected other actions not to trigger the rule. Got %v, %v.", outcomes, err)
+	}
+}
+
+func TestEngineDryRun(t *testing.T) {
+	f := newFakeTrello(t)
+	shippedCard(f, "c1", true)
+	engine, err := NewEngine(f.client(), "b1", shippedRule())
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine.DryRun = true
+	var reported []string
+	engine.OnOutcome = func(o Outcome) { reported = append(reported, o.String()) }
+
+	outcomes, err := engine.HandleEvent(moveEvent("c1", "m-alice"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outcomes) != 4 || !outcomes[0].DryRun {
+		t.Errorf("Expected 4 dry run outcomes. Got %v.", outcomes)
+	}
+	if len(reported) != 4 || reported[0] != "[dry run] Archive shipped cards: comment 'Shipped!' on card 'Card c1'" {
+		t.Errorf("Unexpected reported outcomes %v.", reported)
+	}
+	if len(f.writes) != 0 {
+		t.Errorf("Expected no changes in dry-run mode. Got %v.", f.writes)
+	}
+}
+
+func TestEngineLoopProtection(t *testing.T) {
+	f := newFakeTrello(t)
+	f.addCard(&trello.Card{ID: "c1", Name: "Card", IDList: "l-doing"})
+	rule := &Rule{
+		Name:    "Flag labelled cards",
+		Trigger: Trigger{Actions: []string{"addLabelToCard"}},
+		Actions: []Action{AddLabel("Urgent")},
+	}
+	engine, err := NewEngine(f.client(), "b1", rule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	engine.now = func() time.Time { return now }
+
+	labelled := func(memberID string) trello.ActionEvent {
+		return trello.ActionEvent{Action: &trello.Action{
+			Type:            "addLabelToCard",
+			IDMemberCreator: memberID,
+			Data:            &trello.ActionData{Card: &trello.ActionDataCard{ID: "c1"}},
+		}}
+	}
+	if outcomes, err := engine.HandleEvent(labelled("m-alice")); err != nil || len(outcomes) != 1 {
+		t.Fatalf("Expected the rule to fire. Got %v, %v.", outcomes, err)
+	}
+	if engine.Self != "self" {
+		t.Errorf("Expected Self to be looked up. Got '%s'.", engine.Self)
+	}
+
+	if outcomes, err := engine.HandleEvent(labelled("self")); err != nil || len(outcomes) != 0 {
+		t.Errorf("Expected the rule's own action not to re-trigger it. Got %v, %v.", outcomes, err)
+	}
+	if outcomes, err := engine.HandleEvent(labelled("m-alice")); err != nil || len(outcomes) != 1 {
+		t.Errorf("Expected other members' actions to trigger it. Got %v, %v.", outcomes, err)
+	}
+
+	now = now.Add(DefaultLoopWindow)
+	if outcomes, err := engine.HandleEvent(labelled("self")); err != nil || len(outcomes) != 1 {
+		t.Errorf("Expected the rule to fire after the loop window. Got %v, %v.", outcomes, err)
+	}
+}
+
+func TestLoopGuardForgetsOldActions(t *testing.T) {
+	var self string
+	var window time.Duration
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	g := &loopGuard{self: &self, window: &window, now: func() time.Time { return now }}
+
+	g.acted("Rule", "c1")
+	g.acted("Rule", "c2")
+	now = now.Add(DefaultLoopWindow)
+	g.acted("Rule", "c3")
+	if len(g.recent) != 1 || !g.actedRecently("Rule", "c3") {
+		t.Errorf("Expected only the action within the window to be remembered. Got %v.", g.recent)
+	}
+}
+
+func TestEngineTick(t *testing.T) {
+	f := newFakeTrello(t)
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	soon, later := now.Add(time.Hour), now.Add(72*time.Hour)
+	f.addCard(&trello.Card{ID: "c1", Name: "Soon", IDList: "l-doing", Due: &soon})
+	f.addCard(&trello.Card{ID: "c2", Name: "Later", IDList: "l-doing", Due: &later})
+	f.addCard(&trello.Card{ID: "c3", Name: "Shipped", IDList: "l-shipped"})
+
+	engine, err := NewEngine(f.client(), "b1",
+		&Rule{Name: "Nag", Trigger: Trigger{Every: 24 * time.Hour}, Conditions: []Condition{InList("Doing")}, Actions: []Action{Comment("Still doing?")}},
+		&Rule{Name: "Due soon", Trigger: Trigger{DueWithin: 24 * time.Hour}, Actions: []Action{AddLabel("Urgent")}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine.DryRun = true
+	engine.now = func() time.Time { return now }
+
+	count := func(outcomes []Outcome) map[string]int {
+		counts := map[string]int{}
+		for _, o := range outcomes {
+			counts[o.Rule+" "+o.Card.ID]++
+		}
+		return counts
+	}
+	outcomes, err := engine.Tick()
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts := count(outcomes)
+	if len(outcomes) != 3 || counts["Nag c1"] != 1 || counts["Nag c2"] != 1 || counts["Due soon c1"] != 1 {
+		t.Errorf("Unexpected outcomes %v.", outcomes)
+	}
+
+	now = now.Add(time.Minute)
+	if outcomes, err = engine.Tick(); err != nil || len(outcomes) != 0 {
+		t.Errorf("Expected nothing to fire again so soon. Got %v, %v.", outcomes, err)
+	}
+
+	now = now.Add(48 * time.Hour)
+	outcomes, err = engine.Tick()
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts = count(outcomes)
+	if len(outcomes) != 3 || counts["Due soon c2"] != 1 {
+		t.Errorf("Expected the next day's nag and the second due card. Got %v.", outcomes)
+	}
+	if len(engine.dueFired) != 1 {
+		t.Errorf("Expected the passed due date to be forgotten. Got %v.", engine.dueFired)
+	}
+}
+
+func TestEngineRunDefaultInterval(t *testing.T) {
+	f := newFakeTrello(t)
+	engine, err := NewEngine(f.client(), "b1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	engine.Run(ctx, nil, 0)
+}
+
+func TestConditions(t *testing.T) {
+	f := newFakeTrello(t)
+	board := newBoard(f.client(), "b1")
+	card := &trello.Card{
+		IDList:   "l-doing",
+		IDLabels: []string{"lb-urgent"},
+		Checklists: []*trello.Checklist{
+			{Name: "Done", CheckItems: []trello.CheckItem{{State: "complete"}}},
+			{Name: "Open", CheckItems: []trello.CheckItem{{State: "incomplete"}}},
+		},
+		CustomFieldItems: []*trello.CustomFieldItem{{IDCustomField: "f-priority", IDValue: "o-high"}},
+	}
+
+	tests := []struct {
+		condition Condition
+		met       bool
+	}{
+		{InList("Doing"), true},
+		{InList("Shipped"), false},
+		{HasLabel("Urgent"), true},
+		{HasLabel("green"), false},
+		{Not(HasLabel("green")), true},
+		{ChecklistComplete("Done"), true},
+		{ChecklistComplete("Open"), false},
+		{ChecklistComplete(""), false},
+		{ChecklistComplete("Missing"), false},
+		{CustomFieldIs("Priority", "High"), true},
+		{CustomFieldIs("Priority", "Low"), false},
+	}
+	for _, test := range tests {
+		met, err := test.condition.Match(board, card)
+		if err != nil {
+			t.Errorf("%s: %s", test.condition, err)
+		}
+		if met != test.met {
+			t.Errorf("Expected '%s' to be %t.", test.condition, test.met)
+		}
+	}
+
+	if _, err := InList("Missing").Match(board, card); err == nil || !strings.Contains(err.Error(), "Missing") {
+		t.Errorf("Expected an error for a missing list. Got %v.", err)
+	}
+}
+
+func TestNewEngineValidatesRules(t *testing.T) {
+	client := trello.NewClient("key", "token")
+	if _, err := NewEngine(client, "b1", &Rule{Name: "No trigger", Actions: []Action{Archive()}}); err == nil {
+		t.Error("Expected an error for a rule without a trigger.")
+	}
+	if _, err := NewEngine(client, "b1", shippedRule(), shippedRule()); err == nil {
+		t.Error("Expected an error for two rules with the same name.")
+	}
+}
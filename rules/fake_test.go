@@ -0,0 +1,257 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adlio/trello"
+)
+
+// fakeTrello serves the parts of the Trello API the engine uses, for a
+// single board, from memory.
+type fakeTrello struct {
+	sync.Mutex
+	t            *testing.T
+	server       *httptest.Server
+	lists        []*trello.List
+	labels       []*trello.Label
+	members      []*trello.Member
+	customFields []*trello.CustomField
+	cards        map[string]*trello.Card
+	comments     map[string][]string
+	actions      []*trello.Action // newest first
+	writes       []string
+	failures     map[string]int // "METHOD /path" to the times to fail it
+}
+
+func newFakeTrello(t *testing.T) *fakeTrello {
+	f := &fakeTrello{
+		t: t,
+		lists: []*trello.List{
+			{ID: "l-doing", Name: "Doing", IDBoard: "b1"},
+			{ID: "l-shipped", Name: "Shipped", IDBoard: "b1"},
+		},
+		labels: []*trello.Label{
+			{ID: "lb-urgent", IDBoard: "b1", Name: "Urgent", Color: "red"},
+			{ID: "lb-green", IDBoard: "b1", Color: "green"},
+		},
+		members:  []*trello.Member{{ID: "m-alice", Username: "alice"}, {ID: "self", Username: "bot"}},
+		cards:    map[string]*trello.Card{},
+		comments: map[string][]string{},
+		failures: map[string]int{},
+	}
+	json.Unmarshal([]byte(`[{"id": "f-priority", "name": "Priority", "type": "list", "options": [
+		{"id": "o-high", "idCustomField": "f-priority", "value": {"text": "High"}}
+	]}]`), &f.customFields)
+	f.server = httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakeTrello) client() *trello.Client {
+	c := trello.NewClient("key", "token")
+	c.BaseURL = f.server.URL
+	return c
+}
+
+func (f *fakeTrello) addCard(card *trello.Card) *trello.Card {
+	f.Lock()
+	defer f.Unlock()
+	card.IDBoard = "b1"
+	f.cards[card.ID] = card
+	return card
+}
+
+func (f *fakeTrello) card(id string) *trello.Card {
+	f.Lock()
+	defer f.Unlock()
+	return f.cards[id]
+}
+
+func (f *fakeTrello) serve(rw http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+	path := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if r.Method != http.MethodGet {
+		f.writes = append(f.writes, r.Method+" "+r.URL.Path)
+	}
+	if key := r.Method + " " + r.URL.Path; f.failures[key] > 0 {
+		f.failures[key]--
+		http.Error(rw, "injected failure", http.StatusInternalServerError)
+		return
+	}
+	var result interface{}
+	switch {
+	case r.URL.Path == "/members/me":
+		result = map[string]string{"id": "self"}
+	case r.URL.Path == "/boards/b1/lists":
+		result = f.lists
+	case r.URL.Path == "/boards/b1/labels":
+		result = f.labels
+	case r.URL.Path == "/boards/b1/members":
+		result = f.members
+	case r.URL.Path == "/boards/b1/customFields":
+		result = f.customFields
+	case r.URL.Path == "/boards/b1/cards":
+		cards := []*trello.Card{}
+		if r.FormValue("before") == "" {
+			for _, card := range f.cards {
+				if !card.Closed || r.FormValue("filter") == "all" {
+					cards = append(cards, card)
+				}
+			}
+		}
+		result = cards
+	case r.URL.Path == "/boards/b1/actions":
+		result = []*trello.Action{}
+		if r.FormValue("before") == "" {
+			result = f.actions
+		}
+	case r.Method == http.MethodPost && r.URL.Path == "/cards":
+		result = f.createCard(r)
+	case r.Method == http.MethodPost && len(path) == 3 && path[0] == "checklists" && path[2] == "checkItems":
+		for _, card := range f.cards {
+			for _, checklist := range card.Checklists {
+				if checklist.ID == path[1] {
+					item := trello.CheckItem{ID: fmt.Sprintf("%s-%d", checklist.ID, len(checklist.CheckItems)+1), Name: r.FormValue("name"), State: "incomplete"}
+					checklist.CheckItems = append(checklist.CheckItems, item)
+					result = item
+				}
+			}
+		}
+	case r.Method == http.MethodPost && len(path) == 3 && path[0] == "lists" && path[2] == "archiveAllCards":
+		for _, card := range f.cards {
+			if card.IDList == path[1] {
+				card.Closed = true
+			}
+		}
+		result = map[string]string{}
+	case r.Method == http.MethodPost && len(path) == 3 && path[0] == "lists" && path[2] == "moveAllCards":
+		moved := []*trello.Card{}
+		for _, card := range f.cards {
+			if card.IDList == path[1] && !card.Closed {
+				card.IDList = r.FormValue("idList")
+				moved = append(moved, card)
+			}
+		}
+		result = moved
+	case len(path) == 3 && path[0] == "lists" && path[2] == "cards":
+		cards := []*trello.Card{}
+		for _, card := range f.cards {
+			if card.IDList == path[1] && !card.Closed {
+				cards = append(cards, card)
+			}
+		}
+		result = cards
+	case len(path) >= 2 && path[0] == "cards":
+		card := f.cards[path[1]]
+		if card == nil {
+			http.Error(rw, "card not found", http.StatusNotFound)
+			return
+		}
+		result = f.serveCard(r, card, path[2:])
+	}
+	if result == nil {
+		f.t.Errorf("Unexpected request %s %s.", r.Method, r.URL)
+		http.Error(rw, "unexpected request", http.StatusBadRequest)
+		return
+	}
+	json.NewEncoder(rw).Encode(result)
+}
+
+func (f *fakeTrello) serveCard(r *http.Request, card *trello.Card, rest []string) interface{} {
+	switch {
+	case r.Method == http.MethodGet && len(rest) == 0:
+		return card
+	case r.Method == http.MethodPut && len(rest) == 0:
+		if idList := r.FormValue("idList"); idList != "" {
+			card.IDList = idList
+		}
+		if closed := r.FormValue("closed"); closed != "" {
+			card.Closed = closed == "true"
+		}
+		if desc := r.FormValue("desc"); desc != "" {
+			card.Desc = desc
+		}
+		return card
+	case r.Method == http.MethodDelete && len(rest) == 0:
+		delete(f.cards, card.ID)
+		return map[string]interface{}{"limits": map[string]string{}}
+	case r.Method == http.MethodPost && len(rest) == 1 && rest[0] == "idLabels":
+		card.IDLabels = append(card.IDLabels, r.FormValue("value"))
+		return card.IDLabels
+	case r.Method == http.MethodPost && len(rest) == 1 && rest[0] == "idMembers":
+		card.IDMembers = append(card.IDMembers, r.FormValue("value"))
+		return []*trello.Member{}
+	case r.Method == http.MethodPost && len(rest) == 1 && rest[0] == "checklists":
+		checklist := &trello.Checklist{ID: fmt.Sprintf("%s-cl%d", card.ID, len(card.Checklists)+1), Name: r.FormValue("name"), IDCard: card.ID}
+		card.Checklists = append(card.Checklists, checklist)
+		return checklist
+	case r.Method == http.MethodPut && len(rest) == 3 && rest[0] == "customField":
+		item := &trello.CustomFieldItem{IDCustomField: rest[1], IDModel: card.ID}
+		if err := json.NewDecoder(r.Body).Decode(item); err != nil {
+			f.t.Error(err)
+		}
+		items := []*trello.CustomFieldItem{}
+		for _, existing := range card.CustomFieldItems {
+			if existing.IDCustomField != rest[1] {
+				items = append(items, existing)
+			}
+		}
+		if item.IDValue != "" || item.Value.Get() != nil {
+			items = append(items, item)
+		}
+		card.CustomFieldItems = items
+		return item
+	case r.Method == http.MethodPost && len(rest) == 2 && rest[1] == "comments":
+		f.comments[card.ID] = append(f.comments[card.ID], r.FormValue("text"))
+		return &trello.Action{ID: fmt.Sprintf("comment%d", len(f.comments[card.ID])), Type: "commentCard"}
+	}
+	return nil
+}
+
+// createCard creates a card, or copies the card idCardSource with all of
+// its fields.
+func (f *fakeTrello) createCard(r *http.Request) *trello.Card {
+	card := &trello.Card{}
+	if source := f.cards[r.FormValue("idCardSource")]; source != nil {
+		*card = *source
+		card.Checklists = nil
+		for _, checklist := range source.Checklists {
+			copied := *checklist
+			copied.ID += "-copy"
+			card.Checklists = append(card.Checklists, &copied)
+		}
+	}
+	card.ID = fmt.Sprintf("new%d", len(f.cards)+1)
+	card.IDBoard = "b1"
+	card.IDList = r.FormValue("idList")
+	if name := r.FormValue("name"); name != "" {
+		card.Name = name
+	}
+	if desc := r.FormValue("desc"); desc != "" {
+		card.Desc = desc
+	}
+	if ids := r.FormValue("idLabels"); ids != "" {
+		card.IDLabels = strings.Split(ids, ",")
+	}
+	if ids := r.FormValue("idMembers"); ids != "" {
+		card.IDMembers = strings.Split(ids, ",")
+	}
+	if due, err := time.Parse(time.RFC3339, r.FormValue("due")); err == nil {
+		card.Due = &due
+	}
+	f.cards[card.ID] = card
+	return card
+}
@@ -0,0 +1,12 @@
+module github.com/adlio/trello/rules
+
+go 1.21
+
+require (
+	github.com/adlio/trello v0.0.0-00010101000000-000000000000
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require golang.org/x/time v0.10.0 // indirect
+
+replace github.com/adlio/trello => ../
@@ -0,0 +1,86 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package rules
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/adlio/trello"
+)
+
+// DefaultLoopWindow is how long the events caused by an automation's own
+// actions are ignored, when its LoopWindow is zero.
+const DefaultLoopWindow = time.Minute
+
+// loopGuard remembers which automations recently acted on which cards, so
+// the events their own actions cause don't trigger them again.
+type loopGuard struct {
+	// self and window point at the automation's Self and LoopWindow
+	// fields, so they can be set after it's created.
+	self   *string
+	window *time.Duration
+	now    func() time.Time
+
+	mu     sync.Mutex
+	recent map[[2]string]time.Time
+}
+
+// ownAction returns true if the action was performed by Self, looking Self
+// up first if it isn't set.
+func (g *loopGuard) ownAction(board *Board, a *trello.Action) (bool, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if *g.self == "" {
+		me, err := board.client.GetMember("me")
+		if err != nil {
+			return false, fmt.Errorf("looking up the automation's member: %w", err)
+		}
+		*g.self = me.ID
+	}
+	return a.IDMemberCreator == *g.self, nil
+}
+
+// acted records that the automation named name acted on the card, and
+// forgets the actions which are outside the loop window.
+func (g *loopGuard) acted(name, cardID string) {
+	window := g.windowOrDefault()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.recent == nil {
+		g.recent = map[[2]string]time.Time{}
+	}
+	now := g.now()
+	for key, at := range g.recent {
+		if now.Sub(at) >= window {
+			delete(g.recent, key)
+		}
+	}
+	g.recent[[2]string{name, cardID}] = now
+}
+
+// actedRecently returns true if the automation named name acted on the card
+// within the loop window.
+func (g *loopGuard) actedRecently(name, cardID string) bool {
+	window := g.windowOrDefault()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	key := [2]string{name, cardID}
+	at, ok := g.recent[key]
+	if ok && g.now().Sub(at) >= window {
+		delete(g.recent, key)
+		return false
+	}
+	return ok
+}
+
+func (g *loopGuard) windowOrDefault() time.Duration {
+	if *g.window <= 0 {
+		return DefaultLoopWindow
+	}
+	return *g.window
+}
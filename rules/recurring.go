@@ -0,0 +1,379 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package rules
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/adlio/trello"
+)
+
+// DefaultCatchUp is how far back a Scheduler looks for runs it missed when
+// it starts.
+const DefaultCatchUp = time.Hour
+
+// RecurringCard is a card a Scheduler creates on a cron schedule, either by
+// copying a template card or from the fields below. With a template, the
+// fields add to what's copied.
+type RecurringCard struct {
+	// Name is the name of the created cards. It also identifies them, so it
+	// must be unique within a Scheduler.
+	Name string
+
+	// Schedule is a cron expression, as accepted by ParseSchedule.
+	Schedule string
+
+	// List is the name of the list the cards are created at the bottom of.
+	List string
+
+	// Template is the ID of a card to copy, with its description,
+	// checklists, labels, members and attachments. It may be on another
+	// board.
+	Template string
+
+	Desc       string
+	Labels     []string // label names, or colors for labels without one
+	Members    []string // usernames
+	Checklists []ChecklistSpec
+
+	// Due, when set, makes the cards due this long after the run they're
+	// created for.
+	Due time.Duration
+
+	schedule *Schedule
+}
+
+// ChecklistSpec is a checklist for a RecurringCard.
+type ChecklistSpec struct {
+	Name  string
+	Items []string
+}
+
+// Validate returns an error if the recurring card can't be scheduled.
+func (r *RecurringCard) Validate() error {
+	if r.Name == "" {
+		return errors.New("recurring card has no name")
+	}
+	if r.List == "" {
+		return fmt.Errorf("recurring card '%s' has no list", r.Name)
+	}
+	schedule, err := ParseSchedule(r.Schedule)
+	if err != nil {
+		return fmt.Errorf("recurring card '%s': %w", r.Name, err)
+	}
+	r.schedule = schedule
+	return nil
+}
+
+// RecurringMarker is the line a Scheduler adds to the description of the
+// card it creates for the run of the named recurring card at run. It finds
+// the cards it has already created by it, so a restarted Scheduler doesn't
+// create them again.
+func RecurringMarker(name string, run time.Time) string {
+	return fmt.Sprintf("[recurring: %s @ %s]", name, run.UTC().Format("2006-01-02T15:04Z"))
+}
+
+// Scheduler creates RecurringCards on a board as their schedules come due.
+type Scheduler struct {
+	// DryRun reports the cards which would be created without creating
+	// them.
+	DryRun bool
+
+	// Location is the time zone schedules are evaluated in. It defaults to
+	// time.Local.
+	Location *time.Location
+
+	// CatchUp is how far back the first Tick looks for a run which was
+	// missed while the scheduler wasn't running. Only the latest missed run
+	// of each card is created. It defaults to DefaultCatchUp.
+	CatchUp time.Duration
+
+	// OnOutcome and OnError work as they do for an Engine.
+	OnOutcome func(Outcome)
+	OnError   func(error)
+
+	board *Board
+	cards []*RecurringCard
+
+	mu   sync.Mutex
+	now  func() time.Time
+	last map[string]time.Time
+}
+
+// NewScheduler returns a Scheduler which creates cards on the board with the
+// ID boardID. It returns an error if a card is invalid or two cards have
+// the same name.
+func NewScheduler(client *trello.Client, boardID string, cards ...*RecurringCard) (*Scheduler, error) {
+	names := map[string]bool{}
+	for _, card := range cards {
+		if err := card.Validate(); err != nil {
+			return nil, err
+		}
+		if names[card.Name] {
+			return nil, fmt.Errorf("two recurring cards are named '%s'", card.Name)
+		}
+		names[card.Name] = true
+	}
+	return &Scheduler{
+		board: newBoard(client, boardID),
+		cards: cards,
+		now:   time.Now,
+		last:  map[string]time.Time{},
+	}, nil
+}
+
+// Board returns the board the scheduler creates cards on.
+func (s *Scheduler) Board() *Board {
+	return s.board
+}
+
+type recurringRun struct {
+	card *RecurringCard
+	at   time.Time
+	from time.Time
+}
+
+// Tick creates the cards whose schedules have come due since the last Tick,
+// unless a card with the run's RecurringMarker is already on the board.
+func (s *Scheduler) Tick() ([]Outcome, error) {
+	loc := s.Location
+	if loc == nil {
+		loc = time.Local
+	}
+	catchUp := s.CatchUp
+	if catchUp == 0 {
+		catchUp = DefaultCatchUp
+	}
+	now := s.now().In(loc)
+
+	var runs []recurringRun
+	s.mu.Lock()
+	for _, card := range s.cards {
+		from, ok := s.last[card.Name]
+		if !ok {
+			from = now.Add(-catchUp)
+		}
+		var at time.Time
+		for t := card.schedule.Next(from); !t.IsZero() && !t.After(now); t = card.schedule.Next(t) {
+			at = t
+		}
+		s.last[card.Name] = now
+		if !at.IsZero() {
+			runs = append(runs, recurringRun{card, at, from})
+		}
+	}
+	s.mu.Unlock()
+	if len(runs) == 0 {
+		return nil, nil
+	}
+
+	existing, err := s.markers()
+	if err != nil {
+		s.retry(runs)
+		return nil, fmt.Errorf("fetching the cards on board %s: %w", s.board.ID, err)
+	}
+	var outcomes []Outcome
+	for i, run := range runs {
+		if existing[RecurringMarker(run.card.Name, run.at)] {
+			continue
+		}
+		o, err := s.create(run.card, run.at)
+		outcomes = append(outcomes, o)
+		if s.OnOutcome != nil {
+			s.OnOutcome(o)
+		}
+		if err != nil {
+			s.retry(runs[i:])
+			return outcomes, err
+		}
+	}
+	return outcomes, nil
+}
+
+// Run calls Tick every interval until ctx is done. Errors are passed to
+// OnError, and don't stop Run. The interval should be a minute or less, as
+// cron schedules have minute resolution; zero or less means
+// DefaultTickInterval.
+func (s *Scheduler) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultTickInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := s.Tick(); err != nil && s.OnError != nil {
+			s.OnError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// retry makes the next Tick consider runs again.
+func (s *Scheduler) retry(runs []recurringRun) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, run := range runs {
+		s.last[run.card.Name] = run.from
+	}
+}
+
+// markers returns the RecurringMarkers on the board's cards, including
+// archived ones.
+func (s *Scheduler) markers() (map[string]bool, error) {
+	cards, err := s.board.board.GetCards(trello.Arguments{"filter": "all", "fields": "desc"})
+	if err != nil {
+		return nil, err
+	}
+	markers := map[string]bool{}
+	for _, card := range cards {
+		for _, line := range strings.Split(card.Desc, "\n") {
+			if line = strings.TrimSpace(line); strings.HasPrefix(line, "[recurring: ") {
+				markers[line] = true
+			}
+		}
+	}
+	return markers, nil
+}
+
+// create creates the card for the run of r at run.
+func (s *Scheduler) create(r *RecurringCard, run time.Time) (Outcome, error) {
+	card := &trello.Card{Name: r.Name}
+	o := Outcome{
+		Rule:   r.Name,
+		Card:   card,
+		Action: fmt.Sprintf("create in list '%s' for %s", r.List, run.Format("2006-01-02 15:04 MST")),
+		DryRun: s.DryRun,
+	}
+	fail := func(err error) (Outcome, error) {
+		o.Err = err
+		return o, fmt.Errorf("%s: %s: %w", r.Name, o.Action, err)
+	}
+
+	list, err := s.board.List(r.List)
+	if err != nil {
+		return fail(err)
+	}
+	card.IDList = list.ID
+	for _, name := range r.Labels {
+		label, err := s.board.Label(name)
+		if err != nil {
+			return fail(err)
+		}
+		card.IDLabels = append(card.IDLabels, label.ID)
+	}
+	for _, username := range r.Members {
+		member, err := s.board.Member(username)
+		if err != nil {
+			return fail(err)
+		}
+		card.IDMembers = append(card.IDMembers, member.ID)
+	}
+	if r.Due > 0 {
+		due := run.Add(r.Due)
+		card.Due = &due
+	}
+	if s.DryRun {
+		return o, nil
+	}
+
+	client := s.board.Client()
+	desc := r.Desc
+	if r.Template != "" {
+		template, err := client.GetCard(r.Template, trello.Arguments{"fields": "desc"})
+		if err != nil {
+			return fail(fmt.Errorf("fetching template card %s: %w", r.Template, err))
+		}
+		if desc == "" {
+			desc = template.Desc
+		}
+		args := trello.Arguments{"keepFromSource": "all", "name": card.Name, "pos": "bottom", "desc": desc}
+		if card.Due != nil {
+			args["due"] = card.Due.Format(time.RFC3339)
+		}
+		copied, err := template.CopyToList(list.ID, args)
+		if err != nil {
+			return fail(err)
+		}
+		o.Card = copied
+		if err := s.complete(copied, card, r, desc, run); err != nil {
+			return fail(err)
+		}
+		return o, nil
+	}
+
+	card.Desc = desc
+	if err := client.CreateCard(card, trello.Arguments{"pos": "bottom"}); err != nil {
+		return fail(err)
+	}
+	if err := s.complete(card, card, r, desc, run); err != nil {
+		return fail(err)
+	}
+	return o, nil
+}
+
+// complete adds the labels and members of want which card doesn't have yet
+// and r's checklists to card, which was just created for the run of r at
+// run, then adds the run's RecurringMarker to its description. The marker
+// goes last so a card is only found by a later Tick once it's finished. If
+// anything fails card is deleted, so retrying the run doesn't leave a
+// half-built card behind.
+func (s *Scheduler) complete(card, want *trello.Card, r *RecurringCard, desc string, run time.Time) error {
+	err := s.finish(card, want, r, desc, run)
+	if err != nil {
+		if derr := card.Delete(); derr != nil {
+			err = fmt.Errorf("%w (and deleting the unfinished card %s: %v)", err, card.ID, derr)
+		}
+	}
+	return err
+}
+
+func (s *Scheduler) finish(card, want *trello.Card, r *RecurringCard, desc string, run time.Time) error {
+	for _, id := range want.IDLabels {
+		if slices.Contains(card.IDLabels, id) {
+			continue
+		}
+		if err := card.AddIDLabel(id); err != nil {
+			return err
+		}
+	}
+	for _, id := range want.IDMembers {
+		if slices.Contains(card.IDMembers, id) {
+			continue
+		}
+		if _, err := card.AddMemberID(id); err != nil {
+			return err
+		}
+	}
+	for _, spec := range r.Checklists {
+		checklist, err := s.board.Client().CreateChecklist(card, spec.Name)
+		if err != nil {
+			return err
+		}
+		for _, item := range spec.Items {
+			if _, err := checklist.CreateCheckItem(item); err != nil {
+				return err
+			}
+		}
+	}
+	return card.Update(trello.Arguments{"desc": withMarker(desc, r.Name, run)})
+}
+
+func withMarker(desc, name string, run time.Time) string {
+	if desc != "" {
+		desc += "\n\n"
+	}
+	return desc + RecurringMarker(name, run)
+}
@@ -0,0 +1,200 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package rules
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adlio/trello"
+)
+
+func TestScheduler(t *testing.T) {
+	f := newFakeTrello(t)
+	// A Monday.
+	now := time.Date(2021, 6, 7, 9, 5, 0, 0, time.UTC)
+	weekly := func() *RecurringCard {
+		return &RecurringCard{
+			Name:       "Weekly ops review",
+			Schedule:   "0 9 * * mon",
+			List:       "Doing",
+			Labels:     []string{"Urgent"},
+			Members:    []string{"alice"},
+			Checklists: []ChecklistSpec{{Name: "Agenda", Items: []string{"Incidents", "On call"}}},
+			Due:        8 * time.Hour,
+		}
+	}
+	newScheduler := func() *Scheduler {
+		s, err := NewScheduler(f.client(), "b1", weekly())
+		if err != nil {
+			t.Fatal(err)
+		}
+		s.Location = time.UTC
+		s.now = func() time.Time { return now }
+		return s
+	}
+
+	s := newScheduler()
+	outcomes, err := s.Tick()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outcomes) != 1 || outcomes[0].String() != "Weekly ops review: create in list 'Doing' for 2021-06-07 09:00 UTC on card 'Weekly ops review'" {
+		t.Fatalf("Unexpected outcomes %v.", outcomes)
+	}
+	card := f.card(outcomes[0].Card.ID)
+	if card == nil || card.IDList != "l-doing" || len(card.IDLabels) != 1 || len(card.IDMembers) != 1 {
+		t.Fatalf("Unexpected card %+v.", card)
+	}
+	if card.Due == nil || !card.Due.Equal(time.Date(2021, 6, 7, 17, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the card to be due at 17:00. Got %v.", card.Due)
+	}
+	if card.Desc != "[recurring: Weekly ops review @ 2021-06-07T09:00Z]" {
+		t.Errorf("Unexpected description %q.", card.Desc)
+	}
+	if len(card.Checklists) != 1 || len(card.Checklists[0].CheckItems) != 2 {
+		t.Errorf("Expected a checklist with two items. Got %+v.", card.Checklists)
+	}
+
+	if outcomes, err := s.Tick(); err != nil || len(outcomes) != 0 {
+		t.Errorf("Expected nothing more to create. Got %v, %v.", outcomes, err)
+	}
+
+	// A restarted scheduler catches up on the run, but finds its card.
+	card.Closed = true
+	now = now.Add(30 * time.Minute)
+	if outcomes, err := newScheduler().Tick(); err != nil || len(outcomes) != 0 {
+		t.Errorf("Expected the restarted scheduler to find the card. Got %v, %v.", outcomes, err)
+	}
+
+	// The next week's run creates another.
+	now = now.AddDate(0, 0, 7)
+	if outcomes, err := s.Tick(); err != nil || len(outcomes) != 1 {
+		t.Errorf("Expected a card for the next week. Got %v, %v.", outcomes, err)
+	}
+}
+
+func TestSchedulerTemplate(t *testing.T) {
+	f := newFakeTrello(t)
+	f.addCard(&trello.Card{
+		ID:         "template",
+		Name:       "Invoice template",
+		Desc:       "Send the invoice.",
+		IDList:     "l-shipped",
+		IDLabels:   []string{"lb-urgent"},
+		Checklists: []*trello.Checklist{{ID: "cl1", Name: "Steps", CheckItems: []trello.CheckItem{{Name: "Send"}}}},
+	})
+	s, err := NewScheduler(f.client(), "b1", &RecurringCard{
+		Name:     "Monthly invoice",
+		Schedule: "@monthly",
+		List:     "Doing",
+		Template: "template",
+		Labels:   []string{"Urgent", "green"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Location = time.UTC
+	s.now = func() time.Time { return time.Date(2021, 7, 1, 0, 10, 0, 0, time.UTC) }
+	outcomes, err := s.Tick()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outcomes) != 1 {
+		t.Fatalf("Unexpected outcomes %v.", outcomes)
+	}
+	card := f.card(outcomes[0].Card.ID)
+	if card.Name != "Monthly invoice" || card.IDList != "l-doing" || len(card.Checklists) != 1 {
+		t.Errorf("Expected a copy of the template. Got %+v.", card)
+	}
+	if !strings.HasPrefix(card.Desc, "Send the invoice.\n\n[recurring: Monthly invoice @ 2021-07-01T00:00Z]") {
+		t.Errorf("Unexpected description %q.", card.Desc)
+	}
+	if len(card.IDLabels) != 2 || card.IDLabels[1] != "lb-green" {
+		t.Errorf("Expected the green label to be added once. Got %v.", card.IDLabels)
+	}
+}
+
+func TestSchedulerRetriesUnfinishedCard(t *testing.T) {
+	f := newFakeTrello(t)
+	s, err := NewScheduler(f.client(), "b1", &RecurringCard{
+		Name:       "Daily standup",
+		Schedule:   "@daily",
+		List:       "Doing",
+		Checklists: []ChecklistSpec{{Name: "Agenda", Items: []string{"Blockers"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Location = time.UTC
+	s.now = func() time.Time { return time.Date(2021, 6, 7, 0, 5, 0, 0, time.UTC) }
+	f.failures["POST /cards/new1/checklists"] = 1
+
+	if outcomes, err := s.Tick(); err == nil || len(outcomes) != 1 || outcomes[0].Err == nil {
+		t.Fatalf("Expected the checklist to fail. Got %v, %v.", outcomes, err)
+	}
+	if len(f.cards) != 0 {
+		t.Fatalf("Expected the unfinished card to be deleted. Got %v.", f.cards)
+	}
+
+	outcomes, err := s.Tick()
+	if err != nil || len(outcomes) != 1 {
+		t.Fatalf("Expected the run to be retried. Got %v, %v.", outcomes, err)
+	}
+	card := f.card(outcomes[0].Card.ID)
+	if len(card.Checklists) != 1 || len(card.Checklists[0].CheckItems) != 1 {
+		t.Errorf("Expected the retried card to have its checklist. Got %+v.", card.Checklists)
+	}
+	if card.Desc != "[recurring: Daily standup @ 2021-06-07T00:00Z]" {
+		t.Errorf("Unexpected description %q.", card.Desc)
+	}
+}
+
+func TestSchedulerDryRun(t *testing.T) {
+	f := newFakeTrello(t)
+	s, err := NewScheduler(f.client(), "b1", &RecurringCard{Name: "Daily", Schedule: "@daily", List: "Doing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.DryRun = true
+	s.now = func() time.Time { return time.Date(2021, 7, 1, 0, 10, 0, 0, time.Local) }
+	outcomes, err := s.Tick()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outcomes) != 1 || !outcomes[0].DryRun {
+		t.Errorf("Unexpected outcomes %v.", outcomes)
+	}
+	if len(f.writes) != 0 {
+		t.Errorf("Expected no changes in dry-run mode. Got %v.", f.writes)
+	}
+}
+
+func TestNewSchedulerErrors(t *testing.T) {
+	f := newFakeTrello(t)
+	for _, cards := range [][]*RecurringCard{
+		{{Name: "No list", Schedule: "@daily"}},
+		{{Name: "Bad schedule", Schedule: "daily", List: "Doing"}},
+		{{Name: "Twice", Schedule: "@daily", List: "Doing"}, {Name: "Twice", Schedule: "@weekly", List: "Doing"}},
+	} {
+		if _, err := NewScheduler(f.client(), "b1", cards...); err == nil {
+			t.Errorf("Expected an error for %s.", cards[0].Name)
+		}
+	}
+}
+
+func TestSchedulerRunDefaultInterval(t *testing.T) {
+	f := newFakeTrello(t)
+	s, err := NewScheduler(f.client(), "b1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s.Run(ctx, 0)
+}
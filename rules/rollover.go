@@ -0,0 +1,299 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/adlio/trello"
+)
+
+// rolloverName is the Rule of the Outcomes in a RolloverReport.
+const rolloverName = "Sprint rollover"
+
+// RolloverPolicy says what Rollover does at the end of a sprint.
+type RolloverPolicy struct {
+	// Done are the names of the lists whose cards are archived.
+	Done []string
+
+	// Unfinished are the names of the lists whose cards are carried over to
+	// the next sprint.
+	Unfinished []string
+
+	// Into is the name of the list unfinished cards are moved to. By
+	// default each goes to the list with the same name as the one it was in.
+	Into string
+
+	// SprintField is the name of a custom field on the next sprint's board
+	// which is set to Sprint on the cards carried over. An empty Sprint
+	// clears it. List fields are set to the option with Sprint's text.
+	SprintField string
+	Sprint      string
+
+	// Comment is commented on the cards carried over. It defaults to a
+	// summary of where they came from and went.
+	Comment string
+
+	// DryRun reports what Rollover would do without doing it.
+	DryRun bool
+}
+
+// RolloverReport is everything Rollover did, or would have done in dry-run
+// mode.
+type RolloverReport struct {
+	DryRun bool
+
+	// Archived are the cards archived from Done lists.
+	Archived []*trello.Card
+
+	// CarriedOver are the cards moved out of Unfinished lists.
+	CarriedOver []*trello.Card
+
+	// Unmapped are the reports of cards moved to another board whose
+	// labels, members or custom fields couldn't all be carried over, by
+	// card ID.
+	Unmapped map[string]*trello.MoveToBoardReport
+
+	// Outcomes are the individual changes, in the order they were made.
+	Outcomes []Outcome
+}
+
+func (r *RolloverReport) String() string {
+	lines := make([]string, len(r.Outcomes))
+	for i, o := range r.Outcomes {
+		lines[i] = o.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (r *RolloverReport) add(card *trello.Card, action string, err error) {
+	r.Outcomes = append(r.Outcomes, Outcome{Rule: rolloverName, Card: card, Action: action, DryRun: r.DryRun, Err: err})
+}
+
+// Rollover ends the sprint on the board from and starts the next one on the
+// board to, which may be the same board. It archives the cards in the
+// policy's Done lists, moves the cards in its Unfinished lists to to, sets
+// their sprint custom field and comments on them. Use boards returned by
+// the client, such as from Client.GetBoard.
+//
+// Within a board whole lists are moved at once. Labels, members and custom
+// fields belong to a board, so cards moving to another board are moved one
+// at a time with Card.MoveToBoard, which carries them over.
+//
+// The report lists everything done before any error.
+func Rollover(from, to *trello.Board, policy RolloverPolicy) (*RolloverReport, error) {
+	report := &RolloverReport{DryRun: policy.DryRun, Unmapped: map[string]*trello.MoveToBoardReport{}}
+	if from.ID == to.ID && policy.Into == "" {
+		return report, errors.New("rolling over within a board needs a list to move unfinished cards Into")
+	}
+
+	fromLists, err := from.GetLists()
+	if err != nil {
+		return report, fmt.Errorf("fetching the lists on board %s: %w", from.ID, err)
+	}
+	toLists := fromLists
+	if to.ID != from.ID {
+		if toLists, err = to.GetLists(); err != nil {
+			return report, fmt.Errorf("fetching the lists on board %s: %w", to.ID, err)
+		}
+	}
+	var sprint *sprintValue
+	if policy.SprintField != "" {
+		field, err := customFieldNamed(to, policy.SprintField)
+		if err != nil {
+			return report, err
+		}
+		if sprint, err = newSprintValue(field, policy.Sprint); err != nil {
+			return report, err
+		}
+	}
+
+	for _, name := range policy.Done {
+		list, err := listNamed(from, fromLists, name)
+		if err != nil {
+			return report, err
+		}
+		cards, err := list.GetCards()
+		if err != nil {
+			return report, fmt.Errorf("fetching the cards in list '%s': %w", name, err)
+		}
+		if len(cards) == 0 {
+			continue
+		}
+		if !policy.DryRun {
+			err = list.ArchiveAllCards()
+		}
+		for _, card := range cards {
+			report.add(card, "archive", err)
+		}
+		if err != nil {
+			return report, fmt.Errorf("archiving the cards in list '%s': %w", name, err)
+		}
+		report.Archived = append(report.Archived, cards...)
+	}
+
+	for _, name := range policy.Unfinished {
+		list, err := listNamed(from, fromLists, name)
+		if err != nil {
+			return report, err
+		}
+		into := policy.Into
+		if into == "" {
+			into = name
+		}
+		target, err := listNamed(to, toLists, into)
+		if err != nil {
+			return report, err
+		}
+		cards, err := list.GetCards()
+		if err != nil {
+			return report, fmt.Errorf("fetching the cards in list '%s': %w", name, err)
+		}
+		if len(cards) == 0 {
+			continue
+		}
+		if err := rolloverMove(report, list, cards, to, target, from.ID == to.ID); err != nil {
+			return report, err
+		}
+		report.CarriedOver = append(report.CarriedOver, cards...)
+
+		comment := policy.Comment
+		if comment == "" {
+			comment = fmt.Sprintf("Carried over from '%s' on board '%s' to '%s' on board '%s'.", list.Name, from.Name, target.Name, to.Name)
+		}
+		for _, card := range cards {
+			if sprint != nil {
+				if err := sprint.set(report, card); err != nil {
+					return report, err
+				}
+			}
+			var err error
+			if !policy.DryRun {
+				_, err = card.AddComment(comment)
+			}
+			report.add(card, fmt.Sprintf("comment '%s'", comment), err)
+			if err != nil {
+				return report, fmt.Errorf("commenting on card %s: %w", card.ID, err)
+			}
+		}
+	}
+	return report, nil
+}
+
+// rolloverMove moves cards, which are all of list's open cards, to target
+// on the board to.
+func rolloverMove(report *RolloverReport, list *trello.List, cards []*trello.Card, to *trello.Board, target *trello.List, sameBoard bool) error {
+	action := fmt.Sprintf("move to list '%s' on board '%s'", target.Name, to.Name)
+	if sameBoard {
+		var err error
+		if !report.DryRun {
+			err = list.MoveAllCards(to.ID, target.ID)
+		}
+		for _, card := range cards {
+			report.add(card, action, err)
+		}
+		if err != nil {
+			return fmt.Errorf("moving the cards in list '%s': %w", list.Name, err)
+		}
+		return nil
+	}
+
+	for _, card := range cards {
+		if report.DryRun {
+			report.add(card, action, nil)
+			continue
+		}
+		moved, err := card.MoveToBoard(to, target, trello.MoveToBoardOptions{Pos: "bottom"})
+		report.add(card, action, err)
+		if err != nil {
+			return fmt.Errorf("moving card %s to board %s: %w", card.ID, to.ID, err)
+		}
+		if !moved.Complete() {
+			report.Unmapped[card.ID] = moved
+		}
+	}
+	return nil
+}
+
+// sprintValue is the value a sprint custom field is set to.
+type sprintValue struct {
+	field    *trello.CustomField
+	text     string
+	value    interface{}
+	optionID string
+}
+
+func newSprintValue(field *trello.CustomField, sprint string) (*sprintValue, error) {
+	v := &sprintValue{field: field, text: sprint}
+	if sprint == "" {
+		return v, nil
+	}
+	switch field.Type {
+	case trello.CustomFieldTypeList:
+		option := field.OptionByText(sprint)
+		if option == nil {
+			return nil, fmt.Errorf("custom field '%s' has no option '%s'", field.Name, sprint)
+		}
+		v.optionID = option.ID
+	case trello.CustomFieldTypeText:
+		v.value = sprint
+	case trello.CustomFieldTypeNumber:
+		n, err := strconv.ParseFloat(sprint, 64)
+		if err != nil {
+			return nil, fmt.Errorf("custom field '%s' is a number, not '%s'", field.Name, sprint)
+		}
+		v.value = n
+	default:
+		return nil, fmt.Errorf("custom field '%s' is a %s field, so it can't hold a sprint", field.Name, field.Type)
+	}
+	return v, nil
+}
+
+// set sets the field on card, or clears it.
+func (v *sprintValue) set(report *RolloverReport, card *trello.Card) error {
+	action := fmt.Sprintf("set custom field '%s' to '%s'", v.field.Name, v.text)
+	if v.text == "" {
+		action = fmt.Sprintf("clear custom field '%s'", v.field.Name)
+	}
+	var err error
+	if !report.DryRun {
+		if v.field.Type == trello.CustomFieldTypeList {
+			err = card.SetCustomFieldOption(v.field.ID, v.optionID)
+		} else {
+			err = card.SetCustomFieldValue(v.field.ID, v.value)
+		}
+	}
+	report.add(card, action, err)
+	if err != nil {
+		return fmt.Errorf("setting custom field '%s' on card %s: %w", v.field.Name, card.ID, err)
+	}
+	return nil
+}
+
+func listNamed(board *trello.Board, lists []*trello.List, name string) (*trello.List, error) {
+	for _, list := range lists {
+		if list.Name == name {
+			return list, nil
+		}
+	}
+	return nil, fmt.Errorf("board %s has no list named '%s'", board.ID, name)
+}
+
+func customFieldNamed(board *trello.Board, name string) (*trello.CustomField, error) {
+	fields, err := board.GetCustomFields()
+	if err != nil {
+		return nil, fmt.Errorf("fetching the custom fields on board %s: %w", board.ID, err)
+	}
+	for _, field := range fields {
+		if field.Name == name {
+			return field, nil
+		}
+	}
+	return nil, fmt.Errorf("board %s has no custom field named '%s'", board.ID, name)
+}
@@ -0,0 +1,99 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package rules
+
+import (
+	"testing"
+
+	"github.com/adlio/trello"
+)
+
+func newRolloverTrello(t *testing.T) (*fakeTrello, *trello.Board) {
+	f := newFakeTrello(t)
+	f.lists = append(f.lists, &trello.List{ID: "l-next", Name: "Next sprint", IDBoard: "b1"})
+	f.customFields = append(f.customFields, &trello.CustomField{ID: "f-sprint", Name: "Sprint", Type: "text"})
+	f.addCard(&trello.Card{ID: "c1", Name: "Shipped", IDList: "l-shipped"})
+	f.addCard(&trello.Card{ID: "c2", Name: "Unfinished", IDList: "l-doing"})
+	board := &trello.Board{ID: "b1", Name: "Team"}
+	board.SetClient(f.client())
+	return f, board
+}
+
+func TestRollover(t *testing.T) {
+	f, board := newRolloverTrello(t)
+	report, err := Rollover(board, board, RolloverPolicy{
+		Done:        []string{"Shipped"},
+		Unfinished:  []string{"Doing"},
+		Into:        "Next sprint",
+		SprintField: "Sprint",
+		Sprint:      "Sprint 24",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Archived) != 1 || len(report.CarriedOver) != 1 {
+		t.Errorf("Expected one card archived and one carried over. Got %v and %v.", report.Archived, report.CarriedOver)
+	}
+	expected := "Sprint rollover: archive on card 'Shipped'\n" +
+		"Sprint rollover: move to list 'Next sprint' on board 'Team' on card 'Unfinished'\n" +
+		"Sprint rollover: set custom field 'Sprint' to 'Sprint 24' on card 'Unfinished'\n" +
+		"Sprint rollover: comment 'Carried over from 'Doing' on board 'Team' to 'Next sprint' on board 'Team'.' on card 'Unfinished'"
+	if report.String() != expected {
+		t.Errorf("Expected the report:\n%s\nGot:\n%s", expected, report)
+	}
+
+	if !f.card("c1").Closed {
+		t.Error("Expected the shipped card to be archived.")
+	}
+	card := f.card("c2")
+	if card.IDList != "l-next" {
+		t.Errorf("Expected the unfinished card in the next sprint. Got %s.", card.IDList)
+	}
+	if len(card.CustomFieldItems) != 1 || card.CustomFieldItems[0].Value.String() != "Sprint 24" {
+		t.Errorf("Expected the sprint to be set. Got %+v.", card.CustomFieldItems)
+	}
+	if len(f.comments["c2"]) != 1 {
+		t.Errorf("Expected a comment. Got %v.", f.comments["c2"])
+	}
+}
+
+func TestRolloverDryRun(t *testing.T) {
+	f, board := newRolloverTrello(t)
+	report, err := Rollover(board, board, RolloverPolicy{
+		Done:        []string{"Shipped"},
+		Unfinished:  []string{"Doing"},
+		Into:        "Next sprint",
+		SprintField: "Priority",
+		Sprint:      "High",
+		DryRun:      true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Outcomes) != 4 || !report.Outcomes[2].DryRun || report.Outcomes[2].Action != "set custom field 'Priority' to 'High'" {
+		t.Errorf("Unexpected outcomes %v.", report.Outcomes)
+	}
+	if len(f.writes) != 0 {
+		t.Errorf("Expected no changes in dry-run mode. Got %v.", f.writes)
+	}
+}
+
+func TestRolloverErrors(t *testing.T) {
+	f, board := newRolloverTrello(t)
+	for _, policy := range []RolloverPolicy{
+		{Unfinished: []string{"Doing"}},
+		{Done: []string{"Done"}, Into: "Next sprint"},
+		{Into: "Next sprint", SprintField: "Iteration"},
+		{Into: "Next sprint", SprintField: "Priority", Sprint: "Sprint 24"},
+	} {
+		if _, err := Rollover(board, board, policy); err == nil {
+			t.Errorf("Expected an error for %+v.", policy)
+		}
+	}
+	if len(f.writes) != 0 {
+		t.Errorf("Expected no changes. Got %v.", f.writes)
+	}
+}
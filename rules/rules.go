@@ -0,0 +1,104 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+// Package rules automates Trello boards with rules in the style of Butler:
+// when a trigger fires for a card, and the card meets the rule's
+// conditions, the rule's actions are applied to it. It is a separate module
+// so the trello package itself doesn't depend on a YAML parser.
+//
+// Rules can be written in Go:
+//
+//	rule := &rules.Rule{
+//		Name:       "Archive shipped cards",
+//		Trigger:    rules.Trigger{Actions: []string{"updateCard:idList"}},
+//		Conditions: []rules.Condition{rules.InList("Shipped"), rules.ChecklistComplete("")},
+//		Actions:    []rules.Action{rules.Comment("Shipped, archiving."), rules.Archive()},
+//	}
+//
+// or loaded from YAML with Load or Parse. An Engine evaluates them against a
+// board's events, from webhooks or Board.Watch, and on a schedule.
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/adlio/trello"
+)
+
+// Rule is a trigger, the conditions a card must meet, and the actions
+// applied to the card when it does.
+type Rule struct {
+	// Name identifies the rule in Outcomes. Rule names must be unique
+	// within an Engine.
+	Name string
+
+	Trigger    Trigger
+	Conditions []Condition
+	Actions    []Action
+}
+
+// Trigger says when a Rule is evaluated. At least one of its fields must be
+// set; a rule with several is evaluated whenever any of them fires.
+type Trigger struct {
+	// Actions are the action types which trigger the rule for the action's
+	// card, e.g. "commentCard" or "addLabelToCard". "updateCard:idList"
+	// matches only cards moving between lists, and "updateCard:closed" only
+	// cards being archived or unarchived.
+	Actions []string
+
+	// Every triggers the rule for each open card on the board at this
+	// interval.
+	Every time.Duration
+
+	// DueWithin triggers the rule once for each incomplete card which falls
+	// due within this window.
+	DueWithin time.Duration
+}
+
+// Validate returns an error if the rule can never fire or do anything.
+func (r *Rule) Validate() error {
+	if r.Name == "" {
+		return errors.New("rule has no name")
+	}
+	t := r.Trigger
+	if len(t.Actions) == 0 && t.Every <= 0 && t.DueWithin <= 0 {
+		return fmt.Errorf("rule '%s' has no trigger", r.Name)
+	}
+	if len(r.Actions) == 0 {
+		return fmt.Errorf("rule '%s' has no actions", r.Name)
+	}
+	return nil
+}
+
+// matchesAction returns true if the trigger fires for the action.
+func (t Trigger) matchesAction(a *trello.Action) bool {
+	for _, actionType := range t.Actions {
+		base, detail, _ := strings.Cut(actionType, ":")
+		if a.Type != base {
+			continue
+		}
+		switch detail {
+		case "":
+			return true
+		case "idList":
+			if a.Data != nil && a.Data.ListAfter != nil {
+				return true
+			}
+		case "closed":
+			if a.DidArchiveCard() || a.DidUnarchiveCard() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// String describes the rule for Outcomes and logs.
+func (r *Rule) String() string {
+	return r.Name
+}
@@ -0,0 +1,302 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/adlio/trello"
+)
+
+// WIPPolicy is what a WIPEnforcer does to a card which takes a list over
+// its limit. Policies can be combined, e.g. WIPComment|WIPMoveBack.
+type WIPPolicy int
+
+// WIP policies.
+const (
+	// WIPComment comments on the card.
+	WIPComment WIPPolicy = 1 << iota
+
+	// WIPLabel adds WIPEnforcer.Label to the card.
+	WIPLabel
+
+	// WIPMoveBack moves the card back to the list it came from. Cards
+	// created in the list stay in it.
+	WIPMoveBack
+)
+
+// listChangeFilter is the filter for the actions which change the number of
+// open cards in a list.
+const listChangeFilter = "createCard,copyCard,emailCard,convertToCardFromCheckItem,moveCardToBoard,moveCardFromBoard,updateCard:idList,updateCard:closed,deleteCard"
+
+// WIPEnforcer enforces work-in-progress limits on a board's lists. Trello's
+// own list limits are only advisory; a WIPEnforcer handles the events for
+// cards entering a list, from webhooks or Board.Watch, and applies its
+// Policy to cards which take the list over its limit.
+type WIPEnforcer struct {
+	// Limits are the maximum number of open cards, by list name.
+	Limits map[string]int
+
+	Policy WIPPolicy
+
+	// Label is the name (or color) of the label WIPLabel adds.
+	Label string
+
+	// Comment is the text WIPComment comments. It defaults to a message
+	// naming the list and its limit.
+	Comment string
+
+	// DryRun, Self, LoopWindow and OnOutcome work as they do for an
+	// Engine.
+	DryRun     bool
+	Self       string
+	LoopWindow time.Duration
+	OnOutcome  func(Outcome)
+
+	board *Board
+	loops *loopGuard
+	now   func() time.Time
+}
+
+// NewWIPEnforcer returns a WIPEnforcer for the board with the ID boardID.
+func NewWIPEnforcer(client *trello.Client, boardID string, limits map[string]int, policy WIPPolicy) *WIPEnforcer {
+	w := &WIPEnforcer{Limits: limits, Policy: policy, board: newBoard(client, boardID), now: time.Now}
+	w.loops = &loopGuard{self: &w.Self, window: &w.LoopWindow, now: func() time.Time { return w.now() }}
+	return w
+}
+
+// Board returns the board the limits are enforced on.
+func (w *WIPEnforcer) Board() *Board {
+	return w.board
+}
+
+// HandleEvent applies the policy to the event's card if the event moved it
+// into a list, taking the list over its limit.
+func (w *WIPEnforcer) HandleEvent(event trello.ActionEvent) ([]Outcome, error) {
+	a := event.Action
+	if a == nil || a.Data == nil || a.Data.Card == nil || !a.DidChangeListForCard() {
+		return nil, nil
+	}
+	entered := trello.ListAfterAction(a)
+	if entered == nil {
+		return nil, nil
+	}
+	list, err := w.board.listByID(entered.ID)
+	if err != nil || list == nil {
+		return nil, err
+	}
+	limit, ok := w.Limits[list.Name]
+	if !ok {
+		return nil, nil
+	}
+	name := fmt.Sprintf("WIP limit of %d on '%s'", limit, list.Name)
+
+	if loop, err := w.loops.ownAction(w.board, a); err != nil {
+		return nil, err
+	} else if loop && w.loops.actedRecently(name, a.Data.Card.ID) {
+		return nil, nil
+	}
+
+	cards, err := list.GetCards(trello.Arguments{"fields": "id"})
+	if err != nil {
+		return nil, fmt.Errorf("counting the cards in '%s': %w", list.Name, err)
+	}
+	if len(cards) <= limit {
+		return nil, nil
+	}
+	card, err := w.board.client.GetCard(a.Data.Card.ID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching card %s: %w", a.Data.Card.ID, err)
+	}
+	if card.IDList != list.ID {
+		// It has already left the list.
+		return nil, nil
+	}
+
+	var actions []Action
+	if w.Policy&WIPComment != 0 {
+		text := w.Comment
+		if text == "" {
+			text = fmt.Sprintf("'%s' has a WIP limit of %d cards, and this card makes %d.", list.Name, limit, len(cards))
+		}
+		actions = append(actions, Comment(text))
+	}
+	if w.Policy&WIPLabel != 0 {
+		actions = append(actions, AddLabel(w.Label))
+	}
+	if w.Policy&WIPMoveBack != 0 && a.Data.ListBefore != nil {
+		actions = append(actions, moveToListID{a.Data.ListBefore})
+	}
+
+	if !w.DryRun {
+		w.loops.acted(name, card.ID)
+	}
+	return applyActions(w.board, name, card, actions, w.DryRun, w.OnOutcome)
+}
+
+// WIPReport is the number of open cards in each of a board's lists over
+// time, reconstructed from the board's actions.
+type WIPReport struct {
+	// Lists are the names of the board's open lists, in board order.
+	Lists []string
+
+	Limits  map[string]int
+	Samples []WIPSample
+}
+
+// WIPSample is the number of open cards in each list at a point in time.
+type WIPSample struct {
+	Time time.Time
+
+	// Counts are the numbers of open cards, by list name.
+	Counts map[string]int
+
+	// Over are the names of the lists over their limits.
+	Over []string
+}
+
+// Breaches returns the number of samples each list was over its limit in,
+// by list name.
+func (r *WIPReport) Breaches() map[string]int {
+	breaches := map[string]int{}
+	for _, sample := range r.Samples {
+		for _, list := range sample.Over {
+			breaches[list]++
+		}
+	}
+	return breaches
+}
+
+// Report reconstructs the number of open cards in each list at every
+// interval from since until now. It starts from the current cards and
+// undoes the board's actions since then, including deleting cards. A card
+// archived before since and deleted after it is counted as open until it
+// was deleted, as deleting it doesn't record that it was archived.
+func (w *WIPEnforcer) Report(since time.Time, interval time.Duration) (*WIPReport, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("report interval must be positive, not %s", interval)
+	}
+	lists, err := w.board.Lists()
+	if err != nil {
+		return nil, err
+	}
+	cards, err := w.board.board.GetCards(trello.Arguments{"filter": "open", "fields": "idList"})
+	if err != nil {
+		return nil, err
+	}
+	actions, err := w.actionsSince(since)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := map[string]int{}
+	for _, card := range cards {
+		counts[card.IDList]++
+	}
+	now := w.now()
+	var times []time.Time
+	for t := since; t.Before(now); t = t.Add(interval) {
+		times = append(times, t)
+	}
+	times = append(times, now)
+
+	report := &WIPReport{Limits: w.Limits, Samples: make([]WIPSample, len(times))}
+	for _, list := range lists {
+		report.Lists = append(report.Lists, list.Name)
+	}
+	// Walk back from now, undoing the actions after each sample's time.
+	archived := archivedWhenDeleted(actions)
+	next := len(actions) - 1
+	for i := len(times) - 1; i >= 0; i-- {
+		for ; next >= 0 && actions[next].Date.After(times[i]); next-- {
+			if !archived[actions[next].ID] {
+				undoListChange(counts, actions[next])
+			}
+		}
+		sample := WIPSample{Time: times[i], Counts: map[string]int{}}
+		for _, list := range lists {
+			sample.Counts[list.Name] = counts[list.ID]
+			if limit, ok := w.Limits[list.Name]; ok && counts[list.ID] > limit {
+				sample.Over = append(sample.Over, list.Name)
+			}
+		}
+		report.Samples[i] = sample
+	}
+	return report, nil
+}
+
+// actionsSince returns the board's list-change actions since the given
+// time, oldest first.
+func (w *WIPEnforcer) actionsSince(since time.Time) (trello.ActionCollection, error) {
+	var actions trello.ActionCollection
+	args := trello.Arguments{"filter": listChangeFilter, "since": since.UTC().Format(time.RFC3339), "limit": strconv.Itoa(1000)}
+	for {
+		page, err := w.board.board.GetActions(args)
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, page...)
+		if len(page) < 1000 {
+			break
+		}
+		sort.Sort(page)
+		args["before"] = page[0].ID
+	}
+	sort.Sort(actions)
+	return actions, nil
+}
+
+// archivedWhenDeleted returns the IDs of the deleteCard actions, among
+// actions sorted oldest first, whose cards had been archived. Those weren't
+// open, so deleting them didn't change any list's count.
+func archivedWhenDeleted(actions trello.ActionCollection) map[string]bool {
+	closed := map[string]bool{}
+	deleted := map[string]bool{}
+	for _, a := range actions {
+		if a.Data == nil || a.Data.Card == nil {
+			continue
+		}
+		switch {
+		case a.Type == "deleteCard":
+			deleted[a.ID] = closed[a.Data.Card.ID]
+		case a.DidArchiveCard():
+			closed[a.Data.Card.ID] = true
+		case a.DidUnarchiveCard():
+			closed[a.Data.Card.ID] = false
+		}
+	}
+	return deleted
+}
+
+// undoListChange updates counts, by list ID, to what they were before a.
+func undoListChange(counts map[string]int, a *trello.Action) {
+	if a.Data == nil {
+		return
+	}
+	switch a.Type {
+	case "createCard", "copyCard", "emailCard", "convertToCardFromCheckItem", "moveCardToBoard":
+		if a.Data.List != nil {
+			counts[a.Data.List.ID]--
+		}
+	case "moveCardFromBoard", "deleteCard":
+		if a.Data.List != nil {
+			counts[a.Data.List.ID]++
+		}
+	case "updateCard":
+		switch {
+		case a.DidArchiveCard() && a.Data.List != nil:
+			counts[a.Data.List.ID]++
+		case a.DidUnarchiveCard() && a.Data.List != nil:
+			counts[a.Data.List.ID]--
+		case a.Data.ListAfter != nil && a.Data.ListBefore != nil:
+			counts[a.Data.ListAfter.ID]--
+			counts[a.Data.ListBefore.ID]++
+		}
+	}
+}
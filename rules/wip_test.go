@@ -0,0 +1,135 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package rules
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adlio/trello"
+)
+
+func TestWIPEnforcer(t *testing.T) {
+	f := newFakeTrello(t)
+	f.addCard(&trello.Card{ID: "c1", Name: "One", IDList: "l-doing"})
+	f.addCard(&trello.Card{ID: "c2", Name: "Two", IDList: "l-doing"})
+	f.addCard(&trello.Card{ID: "c3", Name: "Three", IDList: "l-shipped"})
+
+	w := NewWIPEnforcer(f.client(), "b1", map[string]int{"Doing": 2}, WIPComment|WIPLabel|WIPMoveBack)
+	w.Label = "Urgent"
+
+	if outcomes, err := w.HandleEvent(moveEvent("c2", "m-alice")); err != nil || len(outcomes) != 0 {
+		t.Errorf("Expected no outcomes within the limit. Got %v, %v.", outcomes, err)
+	}
+
+	// c3 moves from Shipped into Doing, making three.
+	f.card("c3").IDList = "l-doing"
+	event := moveEvent("c3", "m-alice")
+	event.Action.Data.ListBefore = &trello.List{ID: "l-shipped", Name: "Shipped"}
+	event.Action.Data.ListAfter = &trello.List{ID: "l-doing", Name: "Doing"}
+	outcomes, err := w.HandleEvent(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outcomes) != 3 || outcomes[2].String() != "WIP limit of 2 on 'Doing': move back to list 'Shipped' on card 'Three'" {
+		t.Fatalf("Unexpected outcomes %v.", outcomes)
+	}
+	card := f.card("c3")
+	if card.IDList != "l-shipped" || len(card.IDLabels) != 1 || card.IDLabels[0] != "lb-urgent" {
+		t.Errorf("Expected the card to be labelled and moved back. Got %+v.", card)
+	}
+	if comments := f.comments["c3"]; len(comments) != 1 || comments[0] != "'Doing' has a WIP limit of 2 cards, and this card makes 3." {
+		t.Errorf("Unexpected comments %v.", comments)
+	}
+
+	// The enforcer's own move back into a list doesn't trigger it again.
+	f.card("c3").IDList = "l-doing"
+	event.Action.IDMemberCreator = "self"
+	if outcomes, err := w.HandleEvent(event); err != nil || len(outcomes) != 0 {
+		t.Errorf("Expected the enforcer's own moves to be ignored. Got %v, %v.", outcomes, err)
+	}
+}
+
+func TestWIPEnforcerDryRun(t *testing.T) {
+	f := newFakeTrello(t)
+	f.addCard(&trello.Card{ID: "c1", Name: "One", IDList: "l-shipped"})
+	f.addCard(&trello.Card{ID: "c2", Name: "Two", IDList: "l-shipped"})
+
+	w := NewWIPEnforcer(f.client(), "b1", map[string]int{"Shipped": 1}, WIPComment|WIPMoveBack)
+	w.DryRun = true
+	w.Comment = "Too much."
+	outcomes, err := w.HandleEvent(moveEvent("c2", "m-alice"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outcomes) != 2 || !outcomes[0].DryRun || outcomes[0].Action != "comment 'Too much.'" {
+		t.Errorf("Unexpected outcomes %v.", outcomes)
+	}
+	if len(f.writes) != 0 {
+		t.Errorf("Expected no changes in dry-run mode. Got %v.", f.writes)
+	}
+}
+
+func TestWIPReport(t *testing.T) {
+	f := newFakeTrello(t)
+	f.addCard(&trello.Card{ID: "c1", IDList: "l-doing"})
+	f.addCard(&trello.Card{ID: "c2", IDList: "l-doing"})
+	f.addCard(&trello.Card{ID: "c3", IDList: "l-shipped"})
+
+	start := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	at := func(hours int) time.Time { return start.Add(time.Duration(hours) * time.Hour) }
+	doing := &trello.List{ID: "l-doing"}
+	shipped := &trello.List{ID: "l-shipped"}
+	half := 30 * time.Minute
+	// Newest first: c4 was archived from Doing and then deleted, c5 was
+	// created in Shipped and deleted, c3 moved on to Shipped, and c2, c3 and
+	// c4 were created in Doing after c1.
+	f.actions = []*trello.Action{
+		{ID: "a6", Type: "deleteCard", Date: at(5).Add(half), Data: &trello.ActionData{List: doing, Card: &trello.ActionDataCard{ID: "c4"}}},
+		{ID: "a5", Type: "updateCard", Date: at(5), Data: &trello.ActionData{List: doing, Card: &trello.ActionDataCard{ID: "c4", Closed: true}, Old: &trello.ActionDataCard{}}},
+		{ID: "a4a", Type: "deleteCard", Date: at(4).Add(half), Data: &trello.ActionData{List: shipped, Card: &trello.ActionDataCard{ID: "c5"}}},
+		{ID: "a4", Type: "updateCard", Date: at(4), Data: &trello.ActionData{ListBefore: doing, ListAfter: shipped}},
+		{ID: "a3a", Type: "createCard", Date: at(3).Add(half), Data: &trello.ActionData{List: shipped, Card: &trello.ActionDataCard{ID: "c5"}}},
+		{ID: "a3", Type: "createCard", Date: at(3), Data: &trello.ActionData{List: doing}},
+		{ID: "a2", Type: "createCard", Date: at(2), Data: &trello.ActionData{List: doing}},
+		{ID: "a1", Type: "createCard", Date: at(1), Data: &trello.ActionData{List: doing}},
+	}
+
+	w := NewWIPEnforcer(f.client(), "b1", map[string]int{"Doing": 3}, WIPComment)
+	w.now = func() time.Time { return at(6) }
+	report, err := w.Report(start, 2*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Lists) != 2 || report.Lists[0] != "Doing" {
+		t.Errorf("Unexpected lists %v.", report.Lists)
+	}
+
+	expected := []struct {
+		hours, doing, shipped int
+	}{{0, 1, 0}, {2, 3, 0}, {4, 3, 2}, {6, 2, 1}}
+	if len(report.Samples) != len(expected) {
+		t.Fatalf("Expected %d samples. Got %d.", len(expected), len(report.Samples))
+	}
+	for i, e := range expected {
+		s := report.Samples[i]
+		if !s.Time.Equal(at(e.hours)) || s.Counts["Doing"] != e.doing || s.Counts["Shipped"] != e.shipped {
+			t.Errorf("Expected %d and %d cards at %d hours. Got %+v.", e.doing, e.shipped, e.hours, s)
+		}
+	}
+
+	// Between 3 and 4 hours Doing had four cards, but no sample falls there.
+	if breaches := report.Breaches(); len(breaches) != 0 {
+		t.Errorf("Expected no breaches. Got %v.", breaches)
+	}
+	report, err = w.Report(start, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if breaches := report.Breaches(); breaches["Doing"] != 1 {
+		t.Errorf("Expected one breach of Doing. Got %v.", breaches)
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package rules
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Load reads rules from the YAML file at path. See Parse for the format.
+func Load(path string) ([]*Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return rules, nil
+}
+
+// Parse reads rules from YAML:
+//
+//	rules:
+//	  - name: Archive shipped cards
+//	    trigger:
+//	      actions: [updateCard:idList]   # and/or every: 24h, dueWithin: 48h
+//	    if:
+//	      - inList: Shipped
+//	      - checklistsComplete: true     # or checklistComplete: <name>
+//	      - customField: {name: Priority, value: High}
+//	      - not: {hasLabel: Keep}
+//	    then:
+//	      - comment: Shipped, archiving.
+//	      - archive: true
+//
+// The other actions are moveToList, addLabel and assign, which take a
+// list name, label name and username.
+func Parse(data []byte) ([]*Rule, error) {
+	var file struct {
+		Rules []ruleSpec `yaml:"rules"`
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil {
+		return nil, err
+	}
+
+	rules := make([]*Rule, 0, len(file.Rules))
+	for i, spec := range file.Rules {
+		rule, err := spec.rule()
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i+1, err)
+		}
+		if err := rule.Validate(); err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+type ruleSpec struct {
+	Name    string `yaml:"name"`
+	Trigger struct {
+		Actions   []string `yaml:"actions"`
+		Every     string   `yaml:"every"`
+		DueWithin string   `yaml:"dueWithin"`
+	} `yaml:"trigger"`
+	If   []conditionSpec `yaml:"if"`
+	Then []actionSpec    `yaml:"then"`
+}
+
+type conditionSpec struct {
+	InList             *string `yaml:"inList"`
+	HasLabel           *string `yaml:"hasLabel"`
+	ChecklistComplete  *string `yaml:"checklistComplete"`
+	ChecklistsComplete *bool   `yaml:"checklistsComplete"`
+	CustomField        *struct {
+		Name  string `yaml:"name"`
+		Value string `yaml:"value"`
+	} `yaml:"customField"`
+	Not *conditionSpec `yaml:"not"`
+}
+
+type actionSpec struct {
+	MoveToList *string `yaml:"moveToList"`
+	AddLabel   *string `yaml:"addLabel"`
+	Assign     *string `yaml:"assign"`
+	Comment    *string `yaml:"comment"`
+	Archive    *bool   `yaml:"archive"`
+}
+
+func (s ruleSpec) rule() (*Rule, error) {
+	rule := &Rule{Name: s.Name, Trigger: Trigger{Actions: s.Trigger.Actions}}
+	var err error
+	if rule.Trigger.Every, err = parseDuration(s.Trigger.Every); err != nil {
+		return nil, fmt.Errorf("every: %w", err)
+	}
+	if rule.Trigger.DueWithin, err = parseDuration(s.Trigger.DueWithin); err != nil {
+		return nil, fmt.Errorf("dueWithin: %w", err)
+	}
+	for _, cs := range s.If {
+		condition, err := cs.condition()
+		if err != nil {
+			return nil, err
+		}
+		rule.Conditions = append(rule.Conditions, condition)
+	}
+	for _, as := range s.Then {
+		action, err := as.action()
+		if err != nil {
+			return nil, err
+		}
+		rule.Actions = append(rule.Actions, action)
+	}
+	return rule, nil
+}
+
+func parseDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(s)
+}
+
+func (s conditionSpec) condition() (Condition, error) {
+	var conditions []Condition
+	if s.InList != nil {
+		conditions = append(conditions, InList(*s.InList))
+	}
+	if s.HasLabel != nil {
+		conditions = append(conditions, HasLabel(*s.HasLabel))
+	}
+	if s.ChecklistComplete != nil {
+		conditions = append(conditions, ChecklistComplete(*s.ChecklistComplete))
+	}
+	if s.ChecklistsComplete != nil {
+		if *s.ChecklistsComplete {
+			conditions = append(conditions, ChecklistComplete(""))
+		} else {
+			conditions = append(conditions, Not(ChecklistComplete("")))
+		}
+	}
+	if s.CustomField != nil {
+		conditions = append(conditions, CustomFieldIs(s.CustomField.Name, s.CustomField.Value))
+	}
+	if s.Not != nil {
+		condition, err := s.Not.condition()
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, Not(condition))
+	}
+	if len(conditions) != 1 {
+		return nil, errors.New("each condition needs exactly one of inList, hasLabel, checklistComplete, checklistsComplete, customField or not")
+	}
+	return conditions[0], nil
+}
+
+func (s actionSpec) action() (Action, error) {
+	var actions []Action
+	if s.MoveToList != nil {
+		actions = append(actions, MoveToList(*s.MoveToList))
+	}
+	if s.AddLabel != nil {
+		actions = append(actions, AddLabel(*s.AddLabel))
+	}
+	if s.Assign != nil {
+		actions = append(actions, Assign(*s.Assign))
+	}
+	if s.Comment != nil {
+		actions = append(actions, Comment(*s.Comment))
+	}
+	if s.Archive != nil && *s.Archive {
+		actions = append(actions, Archive())
+	}
+	if len(actions) != 1 {
+		return nil, errors.New("each action needs exactly one of moveToList, addLabel, assign, comment or archive")
+	}
+	return actions[0], nil
+}
@@ -0,0 +1,100 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testRulesYAML = `
+rules:
+  - name: Archive shipped cards
+    trigger:
+      actions: [updateCard:idList]
+    if:
+      - inList: Shipped
+      - checklistsComplete: true
+      - customField: {name: Priority, value: High}
+      - not: {hasLabel: Keep}
+    then:
+      - comment: Shipped, archiving.
+      - archive: true
+  - name: Chase due cards
+    trigger:
+      every: 24h
+      dueWithin: 48h
+    if:
+      - checklistComplete: Review
+    then:
+      - addLabel: Urgent
+      - assign: alice
+      - moveToList: Doing
+`
+
+func TestParse(t *testing.T) {
+	rules, err := Parse([]byte(testRulesYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != 2 {
+		t.Fatalf("Expected 2 rules. Got %d.", len(rules))
+	}
+
+	shipped := rules[0]
+	if shipped.Name != "Archive shipped cards" || len(shipped.Trigger.Actions) != 1 || shipped.Trigger.Actions[0] != "updateCard:idList" {
+		t.Errorf("Unexpected rule %+v.", shipped)
+	}
+	expected := []string{"in list 'Shipped'", "checklists complete", "custom field 'Priority' is 'High'", "not has label 'Keep'"}
+	for i, condition := range shipped.Conditions {
+		if condition.String() != expected[i] {
+			t.Errorf("Expected condition '%s'. Got '%s'.", expected[i], condition)
+		}
+	}
+	if len(shipped.Actions) != 2 || shipped.Actions[0].String() != "comment 'Shipped, archiving.'" || shipped.Actions[1].String() != "archive" {
+		t.Errorf("Unexpected actions %v.", shipped.Actions)
+	}
+
+	due := rules[1]
+	if due.Trigger.Every != 24*time.Hour || due.Trigger.DueWithin != 48*time.Hour {
+		t.Errorf("Unexpected trigger %+v.", due.Trigger)
+	}
+	expected = []string{"add label 'Urgent'", "assign 'alice'", "move to list 'Doing'"}
+	for i, action := range due.Actions {
+		if action.String() != expected[i] {
+			t.Errorf("Expected action '%s'. Got '%s'.", expected[i], action)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := map[string]string{
+		"two conditions in one": "rules:\n- name: r\n  trigger: {every: 1h}\n  if: [{inList: A, hasLabel: B}]\n  then: [archive: true]",
+		"unknown condition":     "rules:\n- name: r\n  trigger: {every: 1h}\n  if: [{dueSoon: true}]\n  then: [archive: true]",
+		"no actions":            "rules:\n- name: r\n  trigger: {every: 1h}",
+		"no trigger":            "rules:\n- name: r\n  then: [archive: true]",
+		"bad duration":          "rules:\n- name: r\n  trigger: {every: daily}\n  then: [archive: true]",
+		"empty action":          "rules:\n- name: r\n  trigger: {every: 1h}\n  then: [{}]",
+	}
+	for name, yaml := range tests {
+		if _, err := Parse([]byte(yaml)); err == nil {
+			t.Errorf("%s: expected an error.", name)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	if err := os.WriteFile(path, []byte("rules:\n- name: r\n  trigger: {every: daily}\n  then: [archive: true]"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), path) {
+		t.Errorf("Expected the error to name the file. Got %v.", err)
+	}
+}
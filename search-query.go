@@ -0,0 +1,234 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package trello
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Values accepted by the SearchQuery.Due() operator.
+const (
+	SearchDueDay        = "day"
+	SearchDueWeek       = "week"
+	SearchDueMonth      = "month"
+	SearchDueOverdue    = "overdue"
+	SearchDueComplete   = "complete"
+	SearchDueIncomplete = "incomplete"
+)
+
+// Values accepted by the SearchQuery.Is() operator.
+const (
+	SearchIsOpen     = "open"
+	SearchIsArchived = "archived"
+	SearchIsStarred  = "starred"
+)
+
+// Values accepted by the SearchQuery.Has() operator.
+const (
+	SearchHasAttachments = "attachments"
+	SearchHasDescription = "description"
+	SearchHasCover       = "cover"
+	SearchHasMembers     = "members"
+	SearchHasStickers    = "stickers"
+)
+
+// SearchQuery builds a query for Client.Search(). Free text and Trello search
+// operators are collected as SearchTerms, which are rendered into the query
+// string in the order they were added. The remaining fields map onto the
+// paging and scoping parameters of the search endpoint; zero values leave
+// Trello's defaults in place.
+//
+//	q := NewSearchQuery("outage").Board("Ops").Not().Is(SearchIsArchived)
+//	res, err := client.Search(q)
+//
+// https://support.atlassian.com/trello/docs/searching-for-cards-all-boards/
+type SearchQuery struct {
+	Terms      []SearchTerm
+	ModelTypes []string
+	Partial    bool
+
+	IDBoards        []string
+	IDOrganizations []string
+	IDCards         []string
+
+	BoardsLimit        int
+	CardsLimit         int
+	CardsPage          int
+	OrganizationsLimit int
+	MembersLimit       int
+
+	negateNext bool
+}
+
+// NewSearchQuery returns a SearchQuery containing the given free text terms.
+func NewSearchQuery(text ...string) *SearchQuery {
+	q := &SearchQuery{}
+	for _, t := range text {
+		q.Text(t)
+	}
+	return q
+}
+
+// Text adds a free text term to the query.
+func (q *SearchQuery) Text(text string) *SearchQuery {
+	return q.add(quoteSearchValue(text))
+}
+
+// Not negates the next term added to the query, e.g.
+// q.Not().Label("blocked") renders as -label:blocked.
+func (q *SearchQuery) Not() *SearchQuery {
+	q.negateNext = true
+	return q
+}
+
+// Board restricts the results to boards matching name.
+func (q *SearchQuery) Board(name string) *SearchQuery {
+	return q.operator("board", name)
+}
+
+// List restricts the results to cards in lists matching name.
+func (q *SearchQuery) List(name string) *SearchQuery {
+	return q.operator("list", name)
+}
+
+// Label restricts the results to cards with a label matching name or color.
+func (q *SearchQuery) Label(nameOrColor string) *SearchQuery {
+	return q.operator("label", nameOrColor)
+}
+
+// Member restricts the results to cards assigned to the member with the
+// given username. Use "me" for the authenticated member.
+func (q *SearchQuery) Member(username string) *SearchQuery {
+	return q.add("@" + strings.TrimPrefix(username, "@"))
+}
+
+// Name restricts the results to cards whose title matches text.
+func (q *SearchQuery) Name(text string) *SearchQuery {
+	return q.operator("name", text)
+}
+
+// Description restricts the results to cards whose description matches text.
+func (q *SearchQuery) Description(text string) *SearchQuery {
+	return q.operator("description", text)
+}
+
+// Checklist restricts the results to cards with a checklist item matching text.
+func (q *SearchQuery) Checklist(text string) *SearchQuery {
+	return q.operator("checklist", text)
+}
+
+// Comment restricts the results to cards with a comment matching text.
+func (q *SearchQuery) Comment(text string) *SearchQuery {
+	return q.operator("comment", text)
+}
+
+// Due restricts the results by due date. Pass one of the SearchDue constants.
+func (q *SearchQuery) Due(when string) *SearchQuery {
+	return q.operator("due", when)
+}
+
+// DueWithinDays restricts the results to cards due in the next n days.
+func (q *SearchQuery) DueWithinDays(n int) *SearchQuery {
+	return q.operator("due", strconv.Itoa(n))
+}
+
+// Is restricts the results by state. Pass one of the SearchIs constants.
+func (q *SearchQuery) Is(state string) *SearchQuery {
+	return q.operator("is", state)
+}
+
+// Has restricts the results to cards having the given attribute. Pass one
+// of the SearchHas constants.
+func (q *SearchQuery) Has(attribute string) *SearchQuery {
+	return q.operator("has", attribute)
+}
+
+// CreatedWithinDays restricts the results to cards created in the last n days.
+func (q *SearchQuery) CreatedWithinDays(n int) *SearchQuery {
+	return q.operator("created", strconv.Itoa(n))
+}
+
+// EditedWithinDays restricts the results to cards edited in the last n days.
+func (q *SearchQuery) EditedWithinDays(n int) *SearchQuery {
+	return q.operator("edited", strconv.Itoa(n))
+}
+
+// String renders the query's Terms into the query string sent to Trello.
+func (q *SearchQuery) String() string {
+	parts := make([]string, 0, len(q.Terms))
+	for _, term := range q.Terms {
+		if term.Negated {
+			parts = append(parts, "-"+term.Text)
+		} else {
+			parts = append(parts, term.Text)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// ToArguments converts the query into the Arguments of a search request.
+func (q *SearchQuery) ToArguments() Arguments {
+	args := Arguments{
+		"query": q.String(),
+	}
+	if len(q.ModelTypes) > 0 {
+		args["modelTypes"] = strings.Join(q.ModelTypes, ",")
+	}
+	if q.Partial {
+		args["partial"] = "true"
+	}
+	if len(q.IDBoards) > 0 {
+		args["idBoards"] = strings.Join(q.IDBoards, ",")
+	}
+	if len(q.IDOrganizations) > 0 {
+		args["idOrganizations"] = strings.Join(q.IDOrganizations, ",")
+	}
+	if len(q.IDCards) > 0 {
+		args["idCards"] = strings.Join(q.IDCards, ",")
+	}
+	setPositive := func(key string, value int) {
+		if value > 0 {
+			args[key] = strconv.Itoa(value)
+		}
+	}
+	setPositive("boards_limit", q.BoardsLimit)
+	setPositive("cards_limit", q.CardsLimit)
+	setPositive("cards_page", q.CardsPage)
+	setPositive("organizations_limit", q.OrganizationsLimit)
+	setPositive("members_limit", q.MembersLimit)
+	return args
+}
+
+// NextCardsPage returns a copy of the query which requests the following
+// page of cards. Trello returns at most 1000 cards across all pages.
+func (q *SearchQuery) NextCardsPage() *SearchQuery {
+	next := *q
+	next.Terms = slices.Clone(q.Terms)
+	next.CardsPage++
+	return &next
+}
+
+func (q *SearchQuery) operator(name, value string) *SearchQuery {
+	return q.add(fmt.Sprintf("%s:%s", name, quoteSearchValue(value)))
+}
+
+func (q *SearchQuery) add(text string) *SearchQuery {
+	q.Terms = append(q.Terms, SearchTerm{Text: text, Negated: q.negateNext})
+	q.negateNext = false
+	return q
+}
+
+// quoteSearchValue wraps values containing whitespace in double quotes so
+// Trello treats them as a single term.
+func quoteSearchValue(value string) string {
+	if strings.ContainsAny(value, " \t") {
+		return strconv.Quote(value)
+	}
+	return value
+}
@@ -0,0 +1,76 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package trello
+
+import (
+	"testing"
+)
+
+func TestSearchQueryString(t *testing.T) {
+	q := NewSearchQuery("deploy").
+		Board("Release Train").
+		List("Doing").
+		Label("urgent").
+		Member("@alice").
+		Due(SearchDueWeek).
+		Is(SearchIsOpen).
+		Has(SearchHasAttachments).
+		CreatedWithinDays(14).
+		EditedWithinDays(3).
+		Not().Label("blocked")
+
+	expected := `deploy board:"Release Train" list:Doing label:urgent @alice due:week is:open has:attachments created:14 edited:3 -label:blocked`
+	if q.String() != expected {
+		t.Errorf("Expected query\n%s\nGot\n%s", expected, q.String())
+	}
+}
+
+func TestSearchQueryNotOnlyNegatesNextTerm(t *testing.T) {
+	q := NewSearchQuery().Not().Is(SearchIsArchived).Text("report")
+	if q.String() != "-is:archived report" {
+		t.Errorf("Unexpected query '%s'.", q.String())
+	}
+	if !q.Terms[0].Negated || q.Terms[1].Negated {
+		t.Errorf("Expected only the first term to be negated. Got %v.", q.Terms)
+	}
+}
+
+func TestSearchQueryToArguments(t *testing.T) {
+	q := NewSearchQuery("bug")
+	q.ModelTypes = []string{"cards", "boards"}
+	q.IDBoards = []string{"b1", "b2"}
+	q.CardsLimit = 50
+	q.Partial = true
+
+	args := q.ToArguments()
+	expected := Arguments{
+		"query":       "bug",
+		"modelTypes":  "cards,boards",
+		"idBoards":    "b1,b2",
+		"cards_limit": "50",
+		"partial":     "true",
+	}
+	if len(args) != len(expected) {
+		t.Errorf("Expected %d arguments. Got %v.", len(expected), args)
+	}
+	for key, value := range expected {
+		if args[key] != value {
+			t.Errorf("Expected %s=%s. Got '%s'.", key, value, args[key])
+		}
+	}
+}
+
+func TestSearchQueryNextCardsPage(t *testing.T) {
+	q := NewSearchQuery("bug")
+	next := q.NextCardsPage()
+	next.Label("red")
+	if next.CardsPage != 1 || q.CardsPage != 0 {
+		t.Errorf("Expected pages 0 and 1. Got %d and %d.", q.CardsPage, next.CardsPage)
+	}
+	if len(q.Terms) != 1 {
+		t.Errorf("NextCardsPage() should not modify the original query. Got %v.", q.Terms)
+	}
+}
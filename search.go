@@ -8,11 +8,33 @@ package trello
 // SearchResult represents a search result as collections of various
 // types returned by a search, e.g. Cards or Boards.
 type SearchResult struct {
-	Options SearchOptions `json:"options"`
-	Actions []*Action     `json:"actions,omitempty"`
-	Cards   []*Card       `json:"cards,omitempty"`
-	Boards  []*Board      `json:"boards,omitempty"`
-	Members []*Member     `json:"members,omitempty"`
+	Options       SearchOptions   `json:"options"`
+	Actions       []*Action       `json:"actions,omitempty"`
+	Cards         []*Card         `json:"cards,omitempty"`
+	Boards        []*Board        `json:"boards,omitempty"`
+	Members       []*Member       `json:"members,omitempty"`
+	Organizations []*Organization `json:"organizations,omitempty"`
+}
+
+// SetClient can be used to override the internal connection to the Trello
+// API of every model in this SearchResult. Normally, this is set
+// automatically after calls to Search().
+func (r *SearchResult) SetClient(newClient *Client) {
+	for _, action := range r.Actions {
+		action.SetClient(newClient)
+	}
+	for _, card := range r.Cards {
+		card.SetClient(newClient)
+	}
+	for _, board := range r.Boards {
+		board.SetClient(newClient)
+	}
+	for _, member := range r.Members {
+		member.SetClient(newClient)
+	}
+	for _, organization := range r.Organizations {
+		organization.SetClient(newClient)
+	}
 }
 
 // SearchOptions contains options for search requests.
@@ -34,6 +56,19 @@ type SearchTerm struct {
 	Negated bool   `json:"negated,omitempty"`
 }
 
+// Search takes a SearchQuery and Arguments and returns the full SearchResult
+// across all requested model types, or an error. Arguments override the
+// parameters derived from the query.
+func (c *Client) Search(query *SearchQuery, extraArgs ...Arguments) (result *SearchResult, err error) {
+	args := query.ToArguments()
+	args.flatten(extraArgs)
+	err = c.Get("search", args, &result)
+	if result != nil {
+		result.SetClient(c)
+	}
+	return
+}
+
 // SearchCards takes a query string and Arguments and returns a slice of Cards or an error.
 func (c *Client) SearchCards(query string, extraArgs ...Arguments) (cards []*Card, err error) {
 	args := Arguments{
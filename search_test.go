@@ -6,6 +6,7 @@
 package trello
 
 import (
+	"net/http"
 	"testing"
 )
 
@@ -47,3 +48,35 @@ func TestSearchMembers(t *testing.T) {
 		t.Errorf("Expected 3 member search result entries. Got %d.", len(members))
 	}
 }
+
+func TestSearch(t *testing.T) {
+	c := testClient()
+	server := NewMockResponder(t, "search", "full-api-example-response.json")
+	server.AssertRequest(func(t *testing.T, r *http.Request) {
+		if r.URL.Query().Get("query") != "outage board:Ops" {
+			t.Errorf("Unexpected query '%s'.", r.URL.Query().Get("query"))
+		}
+		if r.URL.Query().Get("cards_page") != "2" {
+			t.Errorf("Expected cards_page=2. Got '%s'.", r.URL.Query().Get("cards_page"))
+		}
+	})
+	defer server.Close()
+	c.BaseURL = server.URL()
+
+	q := NewSearchQuery("outage").Board("Ops")
+	q.CardsPage = 2
+	res, err := c.Search(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Actions) != 1 || len(res.Cards) != 1 || len(res.Boards) != 1 || len(res.Organizations) != 1 {
+		t.Errorf("Expected 1 each of actions, cards, boards and organizations. Got %d, %d, %d, %d.",
+			len(res.Actions), len(res.Cards), len(res.Boards), len(res.Organizations))
+	}
+	if len(res.Options.Modifiers) != 1 || res.Options.Modifiers[0].Text != "board:Ops" {
+		t.Errorf("Expected the board:Ops modifier in Options. Got %v.", res.Options.Modifiers)
+	}
+	if res.Cards[0].client == nil || res.Organizations[0].client == nil || res.Actions[0].client == nil {
+		t.Error("Expected search results to have a client.")
+	}
+}
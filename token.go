@@ -41,6 +41,72 @@ func (c *Client) GetToken(tokenID string, extraArgs ...Arguments) (token *Token,
 	return
 }
 
+// CurrentToken returns the Token the Client authenticates with.
+func (c *Client) CurrentToken(extraArgs ...Arguments) (*Token, error) {
+	return c.GetToken(c.Token, extraArgs...)
+}
+
+// GetTokens returns the Tokens the Member has issued to applications.
+func (m *Member) GetTokens(extraArgs ...Arguments) (tokens []*Token, err error) {
+	args := flattenArguments(extraArgs)
+	path := fmt.Sprintf("members/%s/tokens", m.ID)
+	err = m.client.Get(path, args, &tokens)
+	for i := range tokens {
+		tokens[i].SetClient(m.client)
+	}
+	return
+}
+
+// Delete revokes the Token. Requests made with it will fail afterwards.
+func (t *Token) Delete(extraArgs ...Arguments) error {
+	args := flattenArguments(extraArgs)
+	path := fmt.Sprintf("tokens/%s", t.ID)
+	return t.client.Delete(path, args, &map[string]interface{}{})
+}
+
+// CanRead reports whether the Token grants read access to the model (a
+// board, organization or member) with the given ID, either directly or
+// through a wildcard permission.
+func (t *Token) CanRead(modelID string) bool {
+	return t.permitted(modelID, func(p Permission) bool { return p.Read })
+}
+
+// CanWrite reports whether the Token grants write access to the model with
+// the given ID, either directly or through a wildcard permission.
+func (t *Token) CanWrite(modelID string) bool {
+	return t.permitted(modelID, func(p Permission) bool { return p.Write })
+}
+
+func (t *Token) permitted(modelID string, allowed func(Permission) bool) bool {
+	for _, p := range t.Permissions {
+		if (p.IDModel == modelID || p.IDModel == "*") && allowed(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// Expires reports whether the Token has an expiry date. Tokens issued with
+// the "never" expiration don't.
+func (t *Token) Expires() bool {
+	return t.DateExpires != nil
+}
+
+// Expired reports whether the Token's expiry date has passed.
+func (t *Token) Expired() bool {
+	return t.ExpiresWithin(0)
+}
+
+// ExpiresWithin reports whether the Token expires within d from now, e.g.
+// to warn before an integration's token stops working. Tokens which have
+// already expired are included.
+func (t *Token) ExpiresWithin(d time.Duration) bool {
+	if t.DateExpires == nil {
+		return false
+	}
+	return !time.Now().Add(d).Before(*t.DateExpires)
+}
+
 // SetClient can be used to override this Token's internal connection to the
 // Trello API. Normally, this is set automatically after API calls.
 func (t *Token) SetClient(newClient *Client) {
@@ -6,6 +6,7 @@
 package trello
 
 import (
+	"net/http"
 	"testing"
 	"time"
 )
@@ -49,6 +50,118 @@ func TestGetExpiringToken(t *testing.T) {
 	}
 }
 
+func TestCurrentToken(t *testing.T) {
+	c := testClient()
+	server := NewMockResponder(t, "tokens", "token.json")
+	server.AssertRequest(func(t *testing.T, r *http.Request) {
+		if r.URL.Path != "/tokens/"+c.Token {
+			t.Errorf("Expected a request for the client's token. Got %s.", r.URL.Path)
+		}
+	})
+	defer server.Close()
+	c.BaseURL = server.URL()
+
+	token, err := c.CurrentToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.ID != "token-id" || token.client != c {
+		t.Errorf("Unexpected token %s.", token.ID)
+	}
+}
+
+func TestMemberGetTokens(t *testing.T) {
+	c := testClient()
+	server := NewMockResponder(t, "tokens", "member-tokens.json")
+	server.AssertRequest(func(t *testing.T, r *http.Request) {
+		if r.URL.Path != "/members/token-user-id/tokens" {
+			t.Errorf("Unexpected path %s.", r.URL.Path)
+		}
+	})
+	defer server.Close()
+	c.BaseURL = server.URL()
+
+	member := &Member{ID: "token-user-id"}
+	member.SetClient(c)
+	tokens, err := member.GetTokens()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tokens) != 2 {
+		t.Fatalf("Expected 2 tokens. Got %d.", len(tokens))
+	}
+	if tokens[1].Identifier != "Sprint Reports" || tokens[1].client != c {
+		t.Errorf("Unexpected token %s.", tokens[1].Identifier)
+	}
+}
+
+func TestTokenPermissions(t *testing.T) {
+	token := &Token{
+		Permissions: []Permission{
+			{IDModel: "member-id", ModelType: "Member", Read: true},
+			{IDModel: "board-id", ModelType: "Board", Read: true, Write: true},
+		},
+	}
+	if !token.CanRead("member-id") || token.CanWrite("member-id") {
+		t.Error("Expected read-only access to the member.")
+	}
+	if !token.CanRead("board-id") || !token.CanWrite("board-id") {
+		t.Error("Expected read and write access to the board.")
+	}
+	if token.CanRead("other-board-id") || token.CanWrite("other-board-id") {
+		t.Error("Expected no access to other boards.")
+	}
+
+	wildcard := testToken(t)
+	if !wildcard.CanRead("any-board-id") || !wildcard.CanWrite("any-board-id") {
+		t.Error("Expected a wildcard permission to grant access to any model.")
+	}
+}
+
+func TestTokenExpiry(t *testing.T) {
+	never := &Token{}
+	if never.Expires() || never.Expired() || never.ExpiresWithin(24*time.Hour*365) {
+		t.Error("A token without an expiry date should never expire.")
+	}
+
+	soon := time.Now().Add(36 * time.Hour)
+	token := &Token{DateExpires: &soon}
+	if !token.Expires() || token.Expired() {
+		t.Error("Expected an unexpired token with an expiry date.")
+	}
+	if token.ExpiresWithin(24*time.Hour) || !token.ExpiresWithin(48*time.Hour) {
+		t.Error("Expected the token to expire between 24 and 48 hours from now.")
+	}
+
+	expiring := testToken(t)
+	expiring.client.BaseURL = mockResponse("tokens", "token-expiring.json").URL
+	expired, err := expiring.client.GetToken("tOkenId")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !expired.Expired() || !expired.ExpiresWithin(time.Hour) {
+		t.Error("Expected the 2016 token to have expired.")
+	}
+}
+
+func TestTokenDelete(t *testing.T) {
+	c := testClient()
+	server := NewMockResponder(t, "webhooks", "deleted.json")
+	server.AssertRequest(func(t *testing.T, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/tokens/5f9e8d7c6b5a4f3e2d1c0b9a" {
+			t.Errorf("Unexpected request %s %s.", r.Method, r.URL.Path)
+		}
+	})
+	defer server.Close()
+	c.BaseURL = server.URL()
+
+	token := &Token{ID: "5f9e8d7c6b5a4f3e2d1c0b9a"}
+	token.SetClient(c)
+	if err := token.Delete(); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestTokenSetClient(t *testing.T) {
 	tok := Token{}
 	client := testClient()
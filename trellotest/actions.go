@@ -0,0 +1,119 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package trellotest
+
+import (
+	"time"
+
+	"github.com/adlio/trello"
+)
+
+// CreateCardAction returns the createCard action Trello sends when card is
+// created in list.
+func CreateCardAction(board *trello.Board, list *trello.List, card *trello.Card) *trello.Action {
+	return newAction("createCard", &trello.ActionData{
+		Board: boardRef(board),
+		List:  listRef(list),
+		Card:  cardRef(card),
+	})
+}
+
+// MoveCardAction returns the updateCard action Trello sends when card is
+// moved from one list to another.
+func MoveCardAction(board *trello.Board, card *trello.Card, from, to *trello.List) *trello.Action {
+	return newAction("updateCard", &trello.ActionData{
+		Board:      boardRef(board),
+		Card:       cardRef(card),
+		ListBefore: listRef(from),
+		ListAfter:  listRef(to),
+	})
+}
+
+// ArchiveCardAction returns the updateCard action Trello sends when card is
+// archived.
+func ArchiveCardAction(board *trello.Board, list *trello.List, card *trello.Card) *trello.Action {
+	archived := cardRef(card)
+	archived.Closed = true
+	return newAction("updateCard", &trello.ActionData{
+		Board: boardRef(board),
+		List:  listRef(list),
+		Card:  archived,
+		Old:   &trello.ActionDataCard{Closed: false},
+	})
+}
+
+// CommentCardAction returns the commentCard action Trello sends when text is
+// commented on card.
+func CommentCardAction(board *trello.Board, card *trello.Card, text string) *trello.Action {
+	return newAction("commentCard", &trello.ActionData{
+		Board: boardRef(board),
+		Card:  cardRef(card),
+		Text:  text,
+	})
+}
+
+// AddMemberToCardAction returns the addMemberToCard action Trello sends when
+// member is assigned to card.
+func AddMemberToCardAction(board *trello.Board, card *trello.Card, member *trello.Member) *trello.Action {
+	action := newAction("addMemberToCard", &trello.ActionData{
+		Board: boardRef(board),
+		Card:  cardRef(card),
+	})
+	action.Member = &trello.Member{ID: member.ID, Username: member.Username, FullName: member.FullName}
+	return action
+}
+
+// AddLabelToCardAction returns the addLabelToCard action Trello sends when
+// label is added to card.
+func AddLabelToCardAction(board *trello.Board, card *trello.Card, label *trello.Label) *trello.Action {
+	return newAction("addLabelToCard", &trello.ActionData{
+		Board: boardRef(board),
+		Card:  cardRef(card),
+		Label: &trello.Label{ID: label.ID, IDBoard: label.IDBoard, Name: label.Name, Color: label.Color},
+	})
+}
+
+// UpdateCheckItemStateAction returns the updateCheckItemStateOnCard action
+// Trello sends when item, on one of card's checklists, is checked or
+// unchecked. item.State is the state after the change.
+func UpdateCheckItemStateAction(board *trello.Board, card *trello.Card, checklist *trello.Checklist, item *trello.CheckItem) *trello.Action {
+	return newAction("updateCheckItemStateOnCard", &trello.ActionData{
+		Board:     boardRef(board),
+		Card:      cardRef(card),
+		Checklist: &trello.Checklist{ID: checklist.ID, Name: checklist.Name},
+		CheckItem: &trello.CheckItem{ID: item.ID, Name: item.Name, State: item.State},
+	})
+}
+
+func newAction(actionType string, data *trello.ActionData) *trello.Action {
+	return &trello.Action{
+		ID:   NewID(),
+		Type: actionType,
+		Date: time.Now().UTC().Truncate(time.Millisecond),
+		Data: data,
+	}
+}
+
+// The refs copy the fields Trello includes when it refers to a model in an
+// action's data, rather than the whole model.
+
+func boardRef(b *trello.Board) *trello.Board {
+	if b == nil {
+		return nil
+	}
+	return &trello.Board{ID: b.ID, Name: b.Name, ShortURL: b.ShortURL}
+}
+
+func listRef(l *trello.List) *trello.List {
+	if l == nil {
+		return nil
+	}
+	return &trello.List{ID: l.ID, Name: l.Name}
+}
+
+func cardRef(c *trello.Card) *trello.ActionDataCard {
+	return &trello.ActionDataCard{ID: c.ID, Name: c.Name, IDShort: c.IDShort, ShortLink: c.ShortLink, Pos: c.Pos, Closed: c.Closed}
+}
@@ -0,0 +1,26 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+// Package trellotest provides utilities for testing code which uses the
+// trello package, such as a WebhookSender which delivers signed webhook
+// requests to a local handler the way Trello does.
+//
+//	sender := trellotest.NewWebhookSender("https://example.com/trello", appSecret, handler)
+//	resp, err := sender.Send(card, trellotest.CommentCardAction(board, card, "Ship it"))
+package trellotest
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+var idCounter uint64
+
+// NewID returns a new ID in the format Trello uses: a timestamp followed by
+// a counter, so IDs created later sort after earlier ones.
+func NewID() string {
+	return fmt.Sprintf("%08x%016x", time.Now().Unix(), atomic.AddUint64(&idCounter, 1))
+}
@@ -0,0 +1,179 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package trellotest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/adlio/trello"
+)
+
+// SignatureHeader is the header Trello sends a webhook request's signature in.
+const SignatureHeader = trello.WebhookSignatureHeader
+
+// Signature returns the signature Trello sends with a webhook request, as
+// trello.WebhookSignature does. trello.VerifyWebhookSignature checks it.
+func Signature(body []byte, callbackURL, secret string) string {
+	return trello.WebhookSignature(body, callbackURL, secret)
+}
+
+// WebhookSender sends webhook requests the way Trello does, so webhook
+// handlers can be tested without deploying them and changing real boards.
+// It's safe to use from parallel tests.
+type WebhookSender struct {
+	// CallbackURL is the webhook's callback URL. It's part of the signature,
+	// and is where requests are sent when Handler is nil.
+	CallbackURL string
+
+	// Secret is the application secret requests are signed with.
+	Secret string
+
+	// Handler receives the requests directly, without a network round trip.
+	Handler http.Handler
+
+	// HTTPClient sends the requests to CallbackURL when Handler is nil. It
+	// defaults to http.DefaultClient.
+	HTTPClient *http.Client
+
+	// Webhook is sent as the webhook of each request. When it's nil, a
+	// webhook with a new ID is created for each model.
+	Webhook *trello.Webhook
+
+	// MemberCreator is set as the member who performed each action which
+	// doesn't have one.
+	MemberCreator *trello.Member
+
+	mu       sync.Mutex
+	webhooks map[string]*trello.Webhook
+}
+
+// NewWebhookSender returns a WebhookSender which signs requests for
+// callbackURL with secret, and delivers them to handler.
+func NewWebhookSender(callbackURL, secret string, handler http.Handler) *WebhookSender {
+	return &WebhookSender{CallbackURL: callbackURL, Secret: secret, Handler: handler}
+}
+
+// Validate sends the HEAD request Trello sends to a callback URL when a
+// webhook is created. Trello only creates the webhook if the response is a
+// 200.
+func (s *WebhookSender) Validate() (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodHead, s.CallbackURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.do(req)
+}
+
+// Send sends action, which happened on model, in a signed webhook request.
+// model is the model the webhook is registered on: a *trello.Board,
+// *trello.List, *trello.Card, *trello.Member, *trello.Organization,
+// *trello.Checklist or *trello.Label.
+func (s *WebhookSender) Send(model interface{}, action *trello.Action) (*http.Response, error) {
+	body, err := s.Payload(model, action)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, s.CallbackURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Signature(body, s.CallbackURL, s.Secret))
+	return s.do(req)
+}
+
+// Payload returns the body Send sends for action on model.
+func (s *WebhookSender) Payload(model interface{}, action *trello.Action) ([]byte, error) {
+	id, fields, err := modelFields(model)
+	if err != nil {
+		return nil, err
+	}
+	if action.IDMemberCreator == "" && s.MemberCreator != nil {
+		// Fill in a copy, so the caller's action can be sent again.
+		copied := *action
+		copied.IDMemberCreator = s.MemberCreator.ID
+		copied.MemberCreator = s.MemberCreator
+		action = &copied
+	}
+	return json.Marshal(struct {
+		Model   map[string]interface{} `json:"model"`
+		Action  *trello.Action         `json:"action"`
+		Webhook *trello.Webhook        `json:"webhook"`
+	}{fields, action, s.webhook(id)})
+}
+
+func (s *WebhookSender) webhook(idModel string) *trello.Webhook {
+	if s.Webhook != nil {
+		return s.Webhook
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.webhooks == nil {
+		s.webhooks = map[string]*trello.Webhook{}
+	}
+	if s.webhooks[idModel] == nil {
+		s.webhooks[idModel] = &trello.Webhook{ID: NewID(), IDModel: idModel, CallbackURL: s.CallbackURL, Active: true}
+	}
+	return s.webhooks[idModel]
+}
+
+func (s *WebhookSender) do(req *http.Request) (*http.Response, error) {
+	if s.Handler != nil {
+		rec := httptest.NewRecorder()
+		s.Handler.ServeHTTP(rec, req)
+		return rec.Result(), nil
+	}
+	client := s.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return client.Do(req)
+}
+
+// modelFields returns the ID and JSON fields of model, including the fields
+// Trello always sends even when the trello package omits them when empty.
+func modelFields(model interface{}) (id string, fields map[string]interface{}, err error) {
+	defaults := map[string]interface{}{}
+	switch m := model.(type) {
+	case *trello.Board:
+		id = m.ID
+	case *trello.List:
+		id = m.ID
+		defaults = map[string]interface{}{"idBoard": m.IDBoard, "pos": m.Pos}
+	case *trello.Card:
+		id = m.ID
+	case *trello.Member:
+		id = m.ID
+	case *trello.Organization:
+		id = m.ID
+	case *trello.Checklist:
+		id = m.ID
+		defaults = map[string]interface{}{"idBoard": m.IDBoard, "idCard": m.IDCard, "pos": m.Pos, "checkItems": []trello.CheckItem{}}
+	case *trello.Label:
+		id = m.ID
+	default:
+		return "", nil, fmt.Errorf("trellotest: %T isn't a model webhooks can be registered on", model)
+	}
+
+	data, err := json.Marshal(model)
+	if err != nil {
+		return "", nil, err
+	}
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return "", nil, err
+	}
+	for key, value := range defaults {
+		if _, ok := fields[key]; !ok {
+			fields[key] = value
+		}
+	}
+	return
+}
@@ -0,0 +1,197 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package trellotest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/adlio/trello"
+)
+
+const (
+	testCallbackURL = "https://example.com/trello"
+	testSecret      = "appsecret"
+)
+
+func TestSignature(t *testing.T) {
+	signature := Signature([]byte(`{"action":{}}`), testCallbackURL, testSecret)
+	if signature != "KWmvEZqhoC357VxXPpdPl/QpDFw=" {
+		t.Errorf("Unexpected signature '%s'.", signature)
+	}
+}
+
+// receiver records the webhook requests it receives, checking their
+// signatures the way a webhook consumer should.
+type receiver struct {
+	t           *testing.T
+	callbackURL string
+	heads       int
+	events      []*trello.WebhookEvent
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodHead {
+		rc.heads++
+		return
+	}
+	event, err := trello.ParseWebhook(r)
+	if err != nil {
+		rc.t.Error(err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !trello.VerifyWebhookSignature(event.Raw, r.Header.Get(SignatureHeader), rc.callbackURL, testSecret) {
+		http.Error(w, "bad signature", http.StatusUnauthorized)
+		return
+	}
+	rc.events = append(rc.events, event)
+}
+
+func TestWebhookSender(t *testing.T) {
+	rc := &receiver{t: t, callbackURL: testCallbackURL}
+	sender := NewWebhookSender(testCallbackURL, testSecret, rc)
+	sender.MemberCreator = &trello.Member{ID: NewID(), Username: "tester"}
+
+	resp, err := sender.Validate()
+	if err != nil || resp.StatusCode != http.StatusOK || rc.heads != 1 {
+		t.Fatalf("Expected the validation request to succeed. Got %v, %v.", resp, err)
+	}
+
+	board := &trello.Board{ID: NewID(), Name: "Sprint"}
+	todo := &trello.List{ID: NewID(), Name: "To Do", IDBoard: board.ID}
+	done := &trello.List{ID: NewID(), Name: "Done", IDBoard: board.ID}
+	card := &trello.Card{ID: NewID(), Name: "Write tests", IDBoard: board.ID, IDList: todo.ID}
+	checklist := &trello.Checklist{ID: NewID(), Name: "Steps", IDBoard: board.ID, IDCard: card.ID}
+	item := &trello.CheckItem{ID: NewID(), Name: "Review", State: "complete"}
+	label := &trello.Label{ID: NewID(), IDBoard: board.ID, Name: "Urgent", Color: "red"}
+	member := &trello.Member{ID: NewID(), Username: "assignee"}
+	org := &trello.Organization{ID: NewID(), Name: "team", DisplayName: "Team"}
+
+	tests := []struct {
+		model     interface{}
+		action    *trello.Action
+		modelType trello.WebhookModelType
+	}{
+		{board, CreateCardAction(board, todo, card), trello.WebhookModelBoard},
+		{todo, MoveCardAction(board, card, todo, done), trello.WebhookModelList},
+		{card, CommentCardAction(board, card, "LGTM"), trello.WebhookModelCard},
+		{card, ArchiveCardAction(board, done, card), trello.WebhookModelCard},
+		{member, AddMemberToCardAction(board, card, member), trello.WebhookModelMember},
+		{label, AddLabelToCardAction(board, card, label), trello.WebhookModelLabel},
+		{checklist, UpdateCheckItemStateAction(board, card, checklist, item), trello.WebhookModelChecklist},
+		{org, CreateCardAction(board, todo, card), trello.WebhookModelOrganization},
+	}
+	for _, test := range tests {
+		resp, err := sender.Send(test.model, test.action)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Expected a 200 for %s. Got %d.", test.action.Type, resp.StatusCode)
+		}
+		event := rc.events[len(rc.events)-1]
+		if event.Model.Type != test.modelType {
+			t.Errorf("Expected a %s model. Got '%s'.", test.modelType, event.Model.Type)
+		}
+		if event.Action.ID != test.action.ID || event.Action.Type != test.action.Type {
+			t.Errorf("Expected the %s action. Got %+v.", test.action.Type, event.Action)
+		}
+		if event.Action.IDMemberCreator != sender.MemberCreator.ID {
+			t.Errorf("Expected the member creator to be set. Got '%s'.", event.Action.IDMemberCreator)
+		}
+		if test.action.IDMemberCreator != "" || test.action.MemberCreator != nil {
+			t.Errorf("Expected the %s action to be left alone. Got %+v.", test.action.Type, test.action)
+		}
+		if event.Webhook == nil || event.IDModel != event.Model.ID() {
+			t.Errorf("Expected a webhook on the model. Got %+v.", event.Webhook)
+		}
+	}
+
+	move := rc.events[1].Action
+	if !move.DidChangeListForCard() || trello.ListAfterAction(move).ID != done.ID {
+		t.Errorf("Expected the move to be recognized. Got %+v.", move.Data)
+	}
+	if !rc.events[3].Action.DidArchiveCard() {
+		t.Error("Expected the archive to be recognized.")
+	}
+	if rc.events[2].Webhook.ID != rc.events[3].Webhook.ID {
+		t.Error("Expected the same webhook for the same model.")
+	}
+}
+
+func TestWebhookSenderParallel(t *testing.T) {
+	sender := NewWebhookSender(testCallbackURL, testSecret, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {}))
+	sender.MemberCreator = &trello.Member{ID: NewID(), Username: "tester"}
+	board := &trello.Board{ID: NewID(), Name: "Sprint"}
+	todo := &trello.List{ID: NewID(), Name: "To Do", IDBoard: board.ID}
+	action := CreateCardAction(board, todo, &trello.Card{ID: NewID(), Name: "Write tests"})
+
+	for i := 0; i < 4; i++ {
+		t.Run(fmt.Sprintf("sender %d", i), func(t *testing.T) {
+			t.Parallel()
+			card := &trello.Card{ID: NewID(), IDBoard: board.ID, IDList: todo.ID}
+			if _, err := sender.Send(card, action); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestWebhookSenderOverHTTP(t *testing.T) {
+	rc := &receiver{t: t, callbackURL: testCallbackURL}
+	server := httptest.NewServer(rc)
+	defer server.Close()
+	sender := NewWebhookSender(server.URL, testSecret, nil)
+	card := &trello.Card{ID: NewID(), Name: "Remote"}
+
+	resp, err := sender.Send(card, CommentCardAction(nil, card, "Hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected a signature for another callback URL to be rejected. Got %d.", resp.StatusCode)
+	}
+
+	rc.callbackURL = server.URL
+	resp, err = sender.Send(card, CommentCardAction(nil, card, "Hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || len(rc.events) != 1 || rc.events[0].Action.Data.Text != "Hello" {
+		t.Errorf("Expected the comment to be received. Got %d.", resp.StatusCode)
+	}
+}
+
+func TestWebhookSenderWrongSecret(t *testing.T) {
+	store := trello.NewFileWebhookStore(filepath.Join(t.TempDir(), "webhooks.json"))
+	router, err := trello.NewWebhookRouter(trello.NewClient("key", "token"), testCallbackURL, testSecret, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sender := NewWebhookSender(testCallbackURL, "not the secret", router)
+	card := &trello.Card{ID: NewID(), Name: "Forged"}
+
+	resp, err := sender.Send(card, CommentCardAction(nil, card, "Hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected a request signed with the wrong secret to be rejected. Got %d.", resp.StatusCode)
+	}
+}
+
+func TestWebhookSenderRejectsUnknownModels(t *testing.T) {
+	sender := NewWebhookSender(testCallbackURL, testSecret, &receiver{t: t, callbackURL: testCallbackURL})
+	if _, err := sender.Send(&trello.Action{}, &trello.Action{}); err == nil {
+		t.Error("Expected an error for a model webhooks can't be registered on.")
+	}
+}
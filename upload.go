@@ -0,0 +1,87 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package trello
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+)
+
+// Upload describes a file sent to Trello in a multipart request body. The
+// file is streamed from Reader as the request is sent, so uploads of any
+// size use a constant amount of memory.
+type Upload struct {
+	// Filename is reported to Trello as the name of the uploaded file.
+	Filename string
+
+	// Reader supplies the file contents.
+	Reader io.Reader
+
+	// ContentType overrides the Content-Type of the file part. It
+	// defaults to application/octet-stream.
+	ContentType string
+
+	// Size is the length of the file in bytes, if known. It is only
+	// used to report progress.
+	Size int64
+
+	// Progress, when set, is called as the file is sent with the number
+	// of bytes sent so far and the Size of the upload (or -1 if unknown).
+	Progress func(sent, total int64)
+}
+
+// writeTo writes the upload as the single "file" part of a multipart body.
+func (u *Upload) writeTo(writer *multipart.Writer) error {
+	contentType := u.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition",
+		fmt.Sprintf(`form-data; name="file"; filename="%s"`, quoteEscaper.Replace(u.Filename)))
+	header.Set("Content-Type", contentType)
+
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return err
+	}
+
+	var src io.Reader = u.Reader
+	if u.Progress != nil {
+		total := u.Size
+		if total <= 0 {
+			total = -1
+		}
+		src = &progressReader{r: u.Reader, total: total, progress: u.Progress}
+	}
+	_, err = io.Copy(part, src)
+	if err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// progressReader reports the running count of bytes read from r.
+type progressReader struct {
+	r        io.Reader
+	sent     int64
+	total    int64
+	progress func(sent, total int64)
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 {
+		p.sent += int64(n)
+		p.progress(p.sent, p.total)
+	}
+	return n, err
+}
@@ -0,0 +1,119 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package trello
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestUploadAttachmentStreamsMultipartBody(t *testing.T) {
+	card := testCard(t)
+
+	contents := strings.Repeat("0123456789", 100000)
+	server := NewMockResponder(t, "cards", "url-attachments.json")
+	server.AssertRequest(func(t *testing.T, r *http.Request) {
+		if r.ContentLength != -1 {
+			t.Errorf("Expected a streamed body of unknown length. Got ContentLength %d.", r.ContentLength)
+		}
+		if r.URL.Query().Get("mimeType") != "text/plain" || r.URL.Query().Get("name") != "Digits" {
+			t.Errorf("Unexpected arguments '%s'.", r.URL.RawQuery)
+		}
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer file.Close()
+		if header.Filename != "digits.txt" {
+			t.Errorf("Unexpected filename '%s'.", header.Filename)
+		}
+		if header.Header.Get("Content-Type") != "text/plain" {
+			t.Errorf("Unexpected part Content-Type '%s'.", header.Header.Get("Content-Type"))
+		}
+		b, _ := io.ReadAll(file)
+		if string(b) != contents {
+			t.Errorf("Expected %d bytes of file contents. Got %d.", len(contents), len(b))
+		}
+	})
+	defer server.Close()
+	card.client.BaseURL = server.URL()
+
+	var lastSent, lastTotal int64
+	attachment := &Attachment{Name: "Digits", MimeType: "text/plain"}
+	upload := &Upload{
+		Filename: "digits.txt",
+		Reader:   strings.NewReader(contents),
+		Size:     int64(len(contents)),
+		Progress: func(sent, total int64) {
+			lastSent, lastTotal = sent, total
+		},
+	}
+	err := card.UploadAttachment(attachment, upload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lastSent != int64(len(contents)) || lastTotal != int64(len(contents)) {
+		t.Errorf("Expected final progress %d/%d. Got %d/%d.", len(contents), len(contents), lastSent, lastTotal)
+	}
+	if upload.ContentType != "" {
+		t.Error("UploadAttachment() should not modify the caller's Upload.")
+	}
+	if attachment.ID != "5bbce18fa4a337483b145a57" || attachment.Card != card {
+		t.Errorf("Expected attachment to be updated from the response. Got %v.", attachment)
+	}
+}
+
+func TestAddFileAttachmentContentTypeOverride(t *testing.T) {
+	c := testClient()
+	server := NewMockResponder(t, "cards", "url-attachments.json")
+	server.AssertRequest(func(t *testing.T, r *http.Request) {
+		_, header, err := r.FormFile("file")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if header.Header.Get("Content-Type") != "image/png" {
+			t.Errorf("Unexpected part Content-Type '%s'.", header.Header.Get("Content-Type"))
+		}
+	})
+	defer server.Close()
+	c.BaseURL = server.URL()
+
+	err := c.PostUpload("cards/abc/attachments", Defaults(), &Attachment{}, &Upload{
+		Filename:    "logo.png",
+		Reader:      strings.NewReader("png"),
+		ContentType: "image/png",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPostWithBodyReaderError(t *testing.T) {
+	c := testClient()
+	server := NewMockResponder(t, "cards", "url-attachments.json")
+	server.AssertRequest(func(t *testing.T, r *http.Request) {
+		r.ParseMultipartForm(1 << 20)
+	})
+	defer server.Close()
+	c.BaseURL = server.URL()
+
+	readErr := errors.New("disk on fire")
+	err := c.PostWithBody("cards/abc/attachments", Defaults(), &Attachment{}, "broken.bin", io.MultiReader(strings.NewReader("partial"), &failingReader{err: readErr}))
+	if !errors.Is(err, readErr) {
+		t.Fatalf("PostWithBody() should fail with the read error. Got %v.", err)
+	}
+}
+
+type failingReader struct {
+	err error
+}
+
+func (r *failingReader) Read(b []byte) (int, error) {
+	return 0, r.err
+}
@@ -0,0 +1,264 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package trello
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// WebhookSignatureHeader is the header Trello sends a webhook request's
+// signature in.
+const WebhookSignatureHeader = "X-Trello-Webhook"
+
+// MaxWebhookBodySize is the largest webhook request body ParseWebhook and
+// WebhookRouter read, in bytes. Trello's payloads are far smaller; the limit
+// keeps a client from making them buffer an arbitrarily large body.
+const MaxWebhookBodySize = 1 << 20
+
+// WebhookModelType is the kind of model a webhook was registered on.
+type WebhookModelType string
+
+// Webhook model types.
+const (
+	WebhookModelBoard        WebhookModelType = "board"
+	WebhookModelList         WebhookModelType = "list"
+	WebhookModelCard         WebhookModelType = "card"
+	WebhookModelMember       WebhookModelType = "member"
+	WebhookModelOrganization WebhookModelType = "organization"
+	WebhookModelChecklist    WebhookModelType = "checklist"
+	WebhookModelLabel        WebhookModelType = "label"
+)
+
+// WebhookModel is the model a webhook was registered on, as sent with each
+// webhook request. Type says which one of the fields is set.
+type WebhookModel struct {
+	Type         WebhookModelType
+	Board        *Board
+	List         *List
+	Card         *Card
+	Member       *Member
+	Organization *Organization
+	Checklist    *Checklist
+	Label        *Label
+}
+
+// ID returns the ID of the model, or an empty string if the model's type
+// wasn't recognized.
+func (m WebhookModel) ID() string {
+	switch {
+	case m.Board != nil:
+		return m.Board.ID
+	case m.List != nil:
+		return m.List.ID
+	case m.Card != nil:
+		return m.Card.ID
+	case m.Member != nil:
+		return m.Member.ID
+	case m.Organization != nil:
+		return m.Organization.ID
+	case m.Checklist != nil:
+		return m.Checklist.ID
+	case m.Label != nil:
+		return m.Label.ID
+	}
+	return ""
+}
+
+// WebhookEvent is a request Trello sent to a webhook's callback URL. It
+// embeds the ActionEvent, so handlers written for Board.Watch can handle
+// webhooks too.
+type WebhookEvent struct {
+	ActionEvent
+
+	// Model is the model the webhook was registered on, as it was after the
+	// action.
+	Model WebhookModel
+
+	// Webhook is the webhook which sent the request.
+	Webhook *Webhook
+
+	// Raw is the request body as Trello sent it, for fields this package
+	// doesn't decode and for VerifyWebhookSignature.
+	Raw json.RawMessage
+}
+
+// SetClient sets the Client of the event's Action and Model, so they can be
+// used to make further API calls.
+func (e *WebhookEvent) SetClient(newClient *Client) {
+	if e.Action != nil {
+		e.Action.SetClient(newClient)
+	}
+	if e.Webhook != nil {
+		e.Webhook.SetClient(newClient)
+	}
+	m := e.Model
+	switch {
+	case m.Board != nil:
+		m.Board.SetClient(newClient)
+	case m.List != nil:
+		m.List.SetClient(newClient)
+	case m.Card != nil:
+		m.Card.SetClient(newClient)
+	case m.Member != nil:
+		m.Member.SetClient(newClient)
+	case m.Organization != nil:
+		m.Organization.SetClient(newClient)
+	case m.Checklist != nil:
+		m.Checklist.SetClient(newClient)
+	case m.Label != nil:
+		m.Label.SetClient(newClient)
+	}
+}
+
+// WebhookSignature returns the signature Trello sends with a webhook request:
+// the base64 encoded HMAC-SHA1 of the body followed by the callback URL,
+// keyed with the application's secret.
+func WebhookSignature(body []byte, callbackURL, secret string) string {
+	mac := hmac.New(sha1.New, []byte(secret))
+	mac.Write(body)
+	mac.Write([]byte(callbackURL))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhookSignature reports whether signature, the WebhookSignatureHeader
+// of a webhook request, is the signature of body for the webhook's
+// callbackURL and the application's secret. It tells requests from Trello
+// apart from anyone else's who knows the callback URL. The comparison takes
+// constant time.
+func VerifyWebhookSignature(body []byte, signature, callbackURL, secret string) bool {
+	return hmac.Equal([]byte(signature), []byte(WebhookSignature(body, callbackURL, secret)))
+}
+
+// ParseWebhook decodes a request Trello sent to a webhook's callback URL,
+// for webhooks registered on any kind of model. The model's type is
+// detected from the payload; if it isn't recognized, Model.Type is empty
+// and the model is only available in Raw.
+//
+// Trello sends a HEAD request to the callback URL when a webhook is created.
+// For those ParseWebhook returns an empty WebhookEvent; respond with a 200
+// for the webhook to be accepted.
+//
+// Bodies larger than MaxWebhookBodySize are rejected with an error wrapping
+// an *http.MaxBytesError. ParseWebhook doesn't check the request's
+// signature. Check it by passing
+// the event's Raw body to VerifyWebhookSignature.
+func ParseWebhook(r *http.Request) (*WebhookEvent, error) {
+	if r.Method == http.MethodHead {
+		return &WebhookEvent{}, nil
+	}
+	body, err := readWebhookBody(r)
+	if err != nil {
+		return nil, fmt.Errorf("ParseWebhook() failed to read '%s': %w", r.URL, err)
+	}
+	event, err := parseWebhookPayload(body)
+	if err != nil {
+		return nil, fmt.Errorf("ParseWebhook() failed to decode '%s': %w", r.URL, err)
+	}
+	return event, nil
+}
+
+// readWebhookBody reads r's body, up to MaxWebhookBodySize bytes.
+func readWebhookBody(r *http.Request) ([]byte, error) {
+	return io.ReadAll(http.MaxBytesReader(nil, r.Body, MaxWebhookBodySize))
+}
+
+func parseWebhookPayload(body []byte) (*WebhookEvent, error) {
+	var payload struct {
+		Action  *Action
+		Model   json.RawMessage
+		Webhook *Webhook
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+
+	event := &WebhookEvent{
+		ActionEvent: ActionEvent{Action: payload.Action, Source: EventSourceWebhook},
+		Webhook:     payload.Webhook,
+		Raw:         body,
+	}
+	if len(payload.Model) > 0 {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(payload.Model, &fields); err != nil {
+			return nil, err
+		}
+		event.Model.Type = webhookModelType(fields)
+		if target := event.Model.target(); target != nil {
+			if err := json.Unmarshal(payload.Model, target); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	event.IDModel = event.Model.ID()
+	if event.Webhook != nil && event.Webhook.IDModel != "" {
+		event.IDModel = event.Webhook.IDModel
+	}
+	return event, nil
+}
+
+// target allocates the field for m's Type and returns a pointer to decode
+// the model into.
+func (m *WebhookModel) target() interface{} {
+	switch m.Type {
+	case WebhookModelBoard:
+		m.Board = &Board{}
+		return m.Board
+	case WebhookModelList:
+		m.List = &List{}
+		return m.List
+	case WebhookModelCard:
+		m.Card = &Card{}
+		return m.Card
+	case WebhookModelMember:
+		m.Member = &Member{}
+		return m.Member
+	case WebhookModelOrganization:
+		m.Organization = &Organization{}
+		return m.Organization
+	case WebhookModelChecklist:
+		m.Checklist = &Checklist{}
+		return m.Checklist
+	case WebhookModelLabel:
+		m.Label = &Label{}
+		return m.Label
+	}
+	return nil
+}
+
+// webhookModelType identifies a model by the fields only its type has.
+// The checks are ordered so that fields shared between types (e.g. prefs,
+// which boards and organizations both have) are checked after the fields
+// which tell them apart.
+func webhookModelType(fields map[string]json.RawMessage) WebhookModelType {
+	has := func(name string) bool {
+		_, ok := fields[name]
+		return ok
+	}
+	switch {
+	case has("username"):
+		return WebhookModelMember
+	case has("checkItems"):
+		return WebhookModelChecklist
+	case has("idList"):
+		return WebhookModelCard
+	case has("displayName"):
+		return WebhookModelOrganization
+	case has("prefs"), has("labelNames"):
+		return WebhookModelBoard
+	case has("idBoard") && has("pos"):
+		return WebhookModelList
+	case has("idBoard") && has("color"):
+		return WebhookModelLabel
+	}
+	return ""
+}
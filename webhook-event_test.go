@@ -0,0 +1,183 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package trello
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	testWebhookCallbackURL = "http://example.com/trello"
+	testWebhookSecret      = "secret"
+)
+
+// webhookRequest returns a request with the fixture's body, signed as
+// Trello would sign it for testWebhookCallbackURL.
+func webhookRequest(t *testing.T, fixture string) *http.Request {
+	body, err := os.ReadFile(filepath.Join("testdata", "webhooks", fixture))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/trello", bytes.NewReader(body))
+	req.Header.Set(WebhookSignatureHeader, WebhookSignature(body, testWebhookCallbackURL, testWebhookSecret))
+	return req
+}
+
+func TestParseWebhook(t *testing.T) {
+	tests := []struct {
+		fixture    string
+		modelType  WebhookModelType
+		modelID    string
+		actionType string
+	}{
+		{"webhook-to-board-updateCard.json", WebhookModelBoard, "57f039fbc0f98772398d289d", "updateCard"},
+		{"webhook-to-list-createCard.json", WebhookModelList, "57f03a09ace084808ff59272", "createCard"},
+		{"webhook-to-card-commentCard.json", WebhookModelCard, "57f0752fe7074732b13358df", "commentCard"},
+		{"webhook-to-member-addMemberToBoard.json", WebhookModelMember, "4f0b777fd1e39cca3f217850", "addMemberToBoard"},
+		{"webhook-to-organization-addToOrganizationBoard.json", WebhookModelOrganization, "57f03a7bf2c3a3ac8a26e4e4", "addToOrganizationBoard"},
+		{"webhook-to-checklist-updateCheckItemStateOnCard.json", WebhookModelChecklist, "57f1c4123f4d5e6a7b8c9d04", "updateCheckItemStateOnCard"},
+		{"webhook-to-label-updateLabel.json", WebhookModelLabel, "57f03a7bf2c3a3ac8a26e4e5", "updateLabel"},
+	}
+	for _, test := range tests {
+		t.Run(string(test.modelType), func(t *testing.T) {
+			event, err := ParseWebhook(webhookRequest(t, test.fixture))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if event.Model.Type != test.modelType {
+				t.Errorf("Expected a %s model. Got '%s'.", test.modelType, event.Model.Type)
+			}
+			if event.Model.ID() != test.modelID {
+				t.Errorf("Expected model %s. Got '%s'.", test.modelID, event.Model.ID())
+			}
+			if event.IDModel != test.modelID {
+				t.Errorf("Expected IDModel %s. Got '%s'.", test.modelID, event.IDModel)
+			}
+			if event.Action == nil || event.Action.Type != test.actionType {
+				t.Errorf("Expected a %s action. Got %+v.", test.actionType, event.Action)
+			}
+			if event.Source != EventSourceWebhook {
+				t.Errorf("Expected the webhook source. Got '%s'.", event.Source)
+			}
+			if !json.Valid(event.Raw) {
+				t.Error("Expected the raw payload to be kept.")
+			}
+		})
+	}
+}
+
+func TestParseWebhookModelFields(t *testing.T) {
+	event, err := ParseWebhook(webhookRequest(t, "webhook-to-checklist-updateCheckItemStateOnCard.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(event.Model.Checklist.CheckItems) != 2 {
+		t.Errorf("Expected 2 check items. Got %d.", len(event.Model.Checklist.CheckItems))
+	}
+	if event.Action.Data.CheckItem.State != "complete" {
+		t.Errorf("Expected the check item to be complete. Got '%s'.", event.Action.Data.CheckItem.State)
+	}
+	if event.Webhook == nil || event.Webhook.ID != "57f1c02b618bc5da74ad3879" {
+		t.Errorf("Expected the webhook to be decoded. Got %+v.", event.Webhook)
+	}
+
+	event, err = ParseWebhook(webhookRequest(t, "webhook-to-label-updateLabel.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.Model.Label.Color != "red" || event.Action.Data.Label.Name != "Blocked" {
+		t.Errorf("Expected the label to be decoded. Got %+v.", event.Model.Label)
+	}
+
+	c := testClient()
+	event.SetClient(c)
+	if event.Model.Label.client != c || event.Action.client != c || event.Webhook.client != c {
+		t.Error("Expected SetClient to set the client of the action, model and webhook.")
+	}
+}
+
+func TestParseWebhookUnknownModel(t *testing.T) {
+	body := `{"model": {"id": "abc", "something": true}, "action": {"id": "def", "type": "somethingNew"}}`
+	event, err := ParseWebhook(httptest.NewRequest(http.MethodPost, "/trello", bytes.NewBufferString(body)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.Model.Type != "" || event.Model.ID() != "" {
+		t.Errorf("Expected an unrecognized model. Got %+v.", event.Model)
+	}
+	if string(event.Raw) != body {
+		t.Errorf("Expected the raw payload. Got %s.", event.Raw)
+	}
+}
+
+func TestParseWebhookHead(t *testing.T) {
+	event, err := ParseWebhook(httptest.NewRequest(http.MethodHead, "/trello", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.Action != nil || event.Model.Type != "" {
+		t.Errorf("Expected an empty event. Got %+v.", event)
+	}
+}
+
+func TestParseWebhookInvalid(t *testing.T) {
+	_, err := ParseWebhook(httptest.NewRequest(http.MethodPost, "/trello", bytes.NewBufferString(`{"model": [`)))
+	if err == nil {
+		t.Error("Expected an error for an invalid payload.")
+	}
+}
+
+func TestParseWebhookTooLarge(t *testing.T) {
+	body := `{"action": {"data": {"text": "` + strings.Repeat("x", MaxWebhookBodySize) + `"}}}`
+	_, err := ParseWebhook(httptest.NewRequest(http.MethodPost, "/trello", strings.NewReader(body)))
+	var tooLarge *http.MaxBytesError
+	if !errors.As(err, &tooLarge) {
+		t.Errorf("Expected an error for a body over the limit. Got %v.", err)
+	}
+}
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	body := []byte(`{"action":{}}`)
+	signature := WebhookSignature(body, testWebhookCallbackURL, testWebhookSecret)
+	if !VerifyWebhookSignature(body, signature, testWebhookCallbackURL, testWebhookSecret) {
+		t.Error("Expected the signature to verify.")
+	}
+	if VerifyWebhookSignature([]byte(`{"action":{"id":"a1"}}`), signature, testWebhookCallbackURL, testWebhookSecret) {
+		t.Error("Expected a different body not to verify.")
+	}
+	if VerifyWebhookSignature(body, signature, "http://example.com/other", testWebhookSecret) {
+		t.Error("Expected a different callback URL not to verify.")
+	}
+	if VerifyWebhookSignature(body, signature, testWebhookCallbackURL, "other") {
+		t.Error("Expected a different secret not to verify.")
+	}
+	if VerifyWebhookSignature(body, "", testWebhookCallbackURL, testWebhookSecret) {
+		t.Error("Expected a missing signature not to verify.")
+	}
+}
+
+func TestGetBoardWebhookRequest(t *testing.T) {
+	whr, err := GetBoardWebhookRequest(webhookRequest(t, "webhook-to-board-updateCard.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if whr.Model.ID != "57f039fbc0f98772398d289d" || whr.Action.Type != "updateCard" {
+		t.Errorf("Unexpected request %+v.", whr)
+	}
+
+	whr, err = GetBoardWebhookRequest(httptest.NewRequest(http.MethodHead, "/trello", nil))
+	if err != nil || whr == nil || whr.Model != nil {
+		t.Errorf("Expected an empty request for HEAD. Got %+v, %v.", whr, err)
+	}
+}
@@ -0,0 +1,274 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package trello
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// WebhookHandler handles the events a WebhookRouter routes to it. Returning
+// an error makes the router respond with a 500, so Trello retries the
+// request later.
+type WebhookHandler func(*WebhookEvent) error
+
+// WebhookRoute is a webhook registered through a WebhookRouter, and the name
+// of the handler its events are routed to.
+type WebhookRoute struct {
+	WebhookID string `json:"webhookID"`
+	IDModel   string `json:"idModel"`
+	Handler   string `json:"handler"`
+}
+
+// WebhookStore persists a WebhookRouter's routes, so they survive restarts.
+type WebhookStore interface {
+	LoadWebhookRoutes() ([]WebhookRoute, error)
+	SaveWebhookRoutes([]WebhookRoute) error
+}
+
+// FileWebhookStore is a WebhookStore which keeps the routes in a JSON file.
+type FileWebhookStore struct {
+	Path string
+}
+
+// NewFileWebhookStore returns a FileWebhookStore which keeps the routes in
+// the file at path. The file is created the first time routes are saved.
+func NewFileWebhookStore(path string) *FileWebhookStore {
+	return &FileWebhookStore{Path: path}
+}
+
+// LoadWebhookRoutes reads the routes from the file. A missing file holds no
+// routes.
+func (s *FileWebhookStore) LoadWebhookRoutes() (routes []WebhookRoute, err error) {
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(data, &routes)
+	if err != nil {
+		err = fmt.Errorf("reading webhook routes from %s: %w", s.Path, err)
+	}
+	return
+}
+
+// SaveWebhookRoutes replaces the file's contents with routes. The file is
+// replaced atomically, so a crash can't leave it half written.
+func (s *FileWebhookStore) SaveWebhookRoutes(routes []WebhookRoute) error {
+	data, err := json.MarshalIndent(routes, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.Path), filepath.Base(s.Path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.Path)
+}
+
+// WebhookRouter serves a single callback URL for many webhooks. It keeps a
+// registry of the webhooks it registered and the handler for each, and
+// routes the events Trello sends by the webhook's model.
+//
+// Requests which aren't signed with the application's secret for the
+// callback URL get a 401, and bodies larger than MaxWebhookBodySize a 413. Requests for models which were deregistered get a
+// 410 Gone, which makes
+// Trello delete the webhook. Requests for other models it doesn't know get
+// a 404, which Trello retries.
+type WebhookRouter struct {
+	client      *Client
+	callbackURL string
+	secret      string
+	store       WebhookStore
+
+	mu           sync.RWMutex
+	handlers     map[string]WebhookHandler
+	routes       map[string]WebhookRoute // by IDModel
+	pending      map[string]string       // IDModel to handler, while registering
+	deregistered map[string]bool
+}
+
+// NewWebhookRouter returns a WebhookRouter which registers webhooks through
+// client, with Trello sending their events to callbackURL. secret is the
+// application's secret, which Trello signs the events with. Routes saved in
+// store by an earlier router are loaded; their handlers must be added with
+// Handle before events arrive.
+func NewWebhookRouter(client *Client, callbackURL, secret string, store WebhookStore) (*WebhookRouter, error) {
+	routes, err := store.LoadWebhookRoutes()
+	if err != nil {
+		return nil, err
+	}
+	r := &WebhookRouter{
+		client:       client,
+		callbackURL:  callbackURL,
+		secret:       secret,
+		store:        store,
+		handlers:     map[string]WebhookHandler{},
+		routes:       map[string]WebhookRoute{},
+		pending:      map[string]string{},
+		deregistered: map[string]bool{},
+	}
+	for _, route := range routes {
+		r.routes[route.IDModel] = route
+	}
+	return r, nil
+}
+
+// Handle sets the handler with the given name, which Register refers to.
+func (r *WebhookRouter) Handle(name string, handler WebhookHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[name] = handler
+}
+
+// Register creates a webhook on the model with the ID idModel and routes its
+// events to the named handler. Registering a model which is already
+// registered only changes its handler.
+func (r *WebhookRouter) Register(idModel, handler, description string) (*Webhook, error) {
+	r.mu.RLock()
+	route, exists := r.routes[idModel]
+	r.mu.RUnlock()
+	if exists {
+		route.Handler = handler
+		return &Webhook{client: r.client, ID: route.WebhookID, IDModel: idModel, CallbackURL: r.callbackURL, Active: true}, r.save(route)
+	}
+
+	// Trello calls the callback URL before CreateWebhook returns, and may
+	// send events before the route is saved, so the lock can't be held
+	// while it's called. Until then the model's route is pending.
+	r.mu.Lock()
+	r.pending[idModel] = handler
+	delete(r.deregistered, idModel)
+	r.mu.Unlock()
+	webhook := &Webhook{IDModel: idModel, Description: description, CallbackURL: r.callbackURL}
+	if err := r.client.CreateWebhook(webhook); err != nil {
+		r.mu.Lock()
+		delete(r.pending, idModel)
+		r.mu.Unlock()
+		return nil, fmt.Errorf("registering a webhook on %s: %w", idModel, err)
+	}
+	return webhook, r.save(WebhookRoute{WebhookID: webhook.ID, IDModel: idModel, Handler: handler})
+}
+
+// Deregister deletes the webhook on the model with the ID idModel, and stops
+// routing its events.
+func (r *WebhookRouter) Deregister(idModel string) error {
+	r.mu.RLock()
+	route, exists := r.routes[idModel]
+	r.mu.RUnlock()
+	if !exists {
+		return fmt.Errorf("no webhook registered on %s", idModel)
+	}
+
+	webhook := &Webhook{client: r.client, ID: route.WebhookID, IDModel: idModel}
+	if err := webhook.Delete(); err != nil && !IsNotFound(err) {
+		return fmt.Errorf("deregistering the webhook on %s: %w", idModel, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.routes, idModel)
+	r.deregistered[idModel] = true
+	return r.store.SaveWebhookRoutes(r.sortedRoutes())
+}
+
+// Routes returns the registered routes, ordered by IDModel.
+func (r *WebhookRouter) Routes() []WebhookRoute {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.sortedRoutes()
+}
+
+// ServeHTTP implements http.Handler. It answers Trello's HEAD validation
+// requests, and checks the signatures of the other requests, parses them as
+// ParseWebhook does and calls the handler of the webhook's model with them.
+func (r *WebhookRouter) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method == http.MethodHead {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	body, err := readWebhookBody(req)
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !VerifyWebhookSignature(body, req.Header.Get(WebhookSignatureHeader), r.callbackURL, r.secret) {
+		http.Error(w, "invalid webhook signature", http.StatusUnauthorized)
+		return
+	}
+	event, err := parseWebhookPayload(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	r.mu.RLock()
+	route, exists := r.routes[event.IDModel]
+	if name, pending := r.pending[event.IDModel]; !exists && pending {
+		route, exists = WebhookRoute{IDModel: event.IDModel, Handler: name}, true
+	}
+	handler := r.handlers[route.Handler]
+	deregistered := r.deregistered[event.IDModel]
+	r.mu.RUnlock()
+	if !exists && deregistered {
+		http.Error(w, "the webhook for this model was deregistered", http.StatusGone)
+		return
+	}
+	if !exists {
+		http.Error(w, "no webhook registered for this model", http.StatusNotFound)
+		return
+	}
+	if handler == nil {
+		http.Error(w, fmt.Sprintf("no handler named '%s'", route.Handler), http.StatusInternalServerError)
+		return
+	}
+
+	event.SetClient(r.client)
+	if err := handler(event); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (r *WebhookRouter) save(route WebhookRoute) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.routes[route.IDModel] = route
+	delete(r.pending, route.IDModel)
+	return r.store.SaveWebhookRoutes(r.sortedRoutes())
+}
+
+func (r *WebhookRouter) sortedRoutes() []WebhookRoute {
+	routes := make([]WebhookRoute, 0, len(r.routes))
+	for _, route := range r.routes {
+		routes = append(routes, route)
+	}
+	sort.Slice(routes, func(i, j int) bool { return routes[i].IDModel < routes[j].IDModel })
+	return routes
+}
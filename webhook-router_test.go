@@ -0,0 +1,232 @@
+// Copyright © 2016 Aaron Longwell
+//
+// Use of this source code is governed by an MIT license.
+// Details in the LICENSE file.
+
+package trello
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// webhookAPI fakes Trello's webhooks endpoints.
+type webhookAPI struct {
+	sync.Mutex
+	server   *httptest.Server
+	webhooks map[string]string // ID to IDModel
+	created  int
+	onCreate func() // called before a webhook is created
+}
+
+func newWebhookAPI() *webhookAPI {
+	api := &webhookAPI{webhooks: map[string]string{}}
+	api.server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		api.Lock()
+		defer api.Unlock()
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/webhooks":
+			if api.onCreate != nil {
+				api.onCreate()
+			}
+			api.created++
+			id := fmt.Sprintf("webhook%d", api.created)
+			api.webhooks[id] = r.FormValue("idModel")
+			fmt.Fprintf(rw, `{"id": %q, "idModel": %q, "callbackURL": %q, "active": true}`, id, r.FormValue("idModel"), r.FormValue("callbackURL"))
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/webhooks/"):
+			id := strings.TrimPrefix(r.URL.Path, "/webhooks/")
+			if _, ok := api.webhooks[id]; !ok {
+				http.Error(rw, "model not found", http.StatusNotFound)
+				return
+			}
+			delete(api.webhooks, id)
+			rw.Write([]byte(`{"_value": null}`))
+		default:
+			http.Error(rw, "unexpected request", http.StatusBadRequest)
+		}
+	}))
+	return api
+}
+
+func (api *webhookAPI) client() *Client {
+	c := testClient()
+	c.BaseURL = api.server.URL
+	return c
+}
+
+func serveWebhook(router http.Handler, req *http.Request) int {
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestWebhookRouter(t *testing.T) {
+	api := newWebhookAPI()
+	defer api.server.Close()
+	store := NewFileWebhookStore(filepath.Join(t.TempDir(), "webhooks.json"))
+
+	router, err := NewWebhookRouter(api.client(), testWebhookCallbackURL, testWebhookSecret, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var handled []string
+	router.Handle("boards", func(e *WebhookEvent) error {
+		handled = append(handled, e.Model.ID())
+		if e.Model.Board.client == nil {
+			t.Error("Expected the event to have a client.")
+		}
+		return nil
+	})
+
+	webhook, err := router.Register("57f039fbc0f98772398d289d", "boards", "Customer board")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if webhook.ID != "webhook1" || webhook.CallbackURL != testWebhookCallbackURL {
+		t.Errorf("Unexpected webhook %+v.", webhook)
+	}
+
+	if code := serveWebhook(router, webhookRequest(t, "webhook-to-board-updateCard.json")); code != http.StatusOK {
+		t.Errorf("Expected a 200. Got %d.", code)
+	}
+	if len(handled) != 1 || handled[0] != "57f039fbc0f98772398d289d" {
+		t.Errorf("Expected the board's event to be handled. Got %v.", handled)
+	}
+
+	if code := serveWebhook(router, webhookRequest(t, "webhook-to-card-commentCard.json")); code != http.StatusNotFound {
+		t.Errorf("Expected a 404 for an unregistered model. Got %d.", code)
+	}
+	if code := serveWebhook(router, httptest.NewRequest(http.MethodHead, "/trello", nil)); code != http.StatusOK {
+		t.Errorf("Expected a 200 for the validation request. Got %d.", code)
+	}
+
+	forged := webhookRequest(t, "webhook-to-board-updateCard.json")
+	forged.Header.Set(WebhookSignatureHeader, WebhookSignature([]byte("{}"), testWebhookCallbackURL, testWebhookSecret))
+	if code := serveWebhook(router, forged); code != http.StatusUnauthorized {
+		t.Errorf("Expected a 401 for a request with the wrong signature. Got %d.", code)
+	}
+	unsigned := webhookRequest(t, "webhook-to-board-updateCard.json")
+	unsigned.Header.Del(WebhookSignatureHeader)
+	if code := serveWebhook(router, unsigned); code != http.StatusUnauthorized {
+		t.Errorf("Expected a 401 for an unsigned request. Got %d.", code)
+	}
+	large := httptest.NewRequest(http.MethodPost, "/trello", strings.NewReader(strings.Repeat(" ", MaxWebhookBodySize+1)))
+	if code := serveWebhook(router, large); code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected a 413 for a body over the limit. Got %d.", code)
+	}
+	if len(handled) != 1 {
+		t.Errorf("Expected the rejected requests not to be handled. Got %v.", handled)
+	}
+
+	if err := router.Deregister("57f039fbc0f98772398d289d"); err != nil {
+		t.Fatal(err)
+	}
+	if len(api.webhooks) != 0 || len(router.Routes()) != 0 {
+		t.Errorf("Expected the webhook to be deleted. Got %v and %v.", api.webhooks, router.Routes())
+	}
+	if code := serveWebhook(router, webhookRequest(t, "webhook-to-board-updateCard.json")); code != http.StatusGone {
+		t.Errorf("Expected a 410 after deregistering. Got %d.", code)
+	}
+	if err := router.Deregister("57f039fbc0f98772398d289d"); err == nil {
+		t.Error("Expected an error deregistering an unknown model.")
+	}
+}
+
+func TestWebhookRouterDuringRegister(t *testing.T) {
+	api := newWebhookAPI()
+	defer api.server.Close()
+	store := NewFileWebhookStore(filepath.Join(t.TempDir(), "webhooks.json"))
+	router, err := NewWebhookRouter(api.client(), testWebhookCallbackURL, testWebhookSecret, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	handled := 0
+	router.Handle("boards", func(e *WebhookEvent) error {
+		handled++
+		return nil
+	})
+
+	// Trello may send events before CreateWebhook returns.
+	code := 0
+	api.onCreate = func() {
+		code = serveWebhook(router, webhookRequest(t, "webhook-to-board-updateCard.json"))
+	}
+	if _, err := router.Register("57f039fbc0f98772398d289d", "boards", ""); err != nil {
+		t.Fatal(err)
+	}
+	if code != http.StatusOK || handled != 1 {
+		t.Errorf("Expected the event sent while registering to be handled. Got %d and %d.", code, handled)
+	}
+
+	// A failed registration leaves no route behind.
+	api.onCreate = nil
+	api.server.Close()
+	if _, err := router.Register("57f0752fe7074732b13358df", "boards", ""); err == nil {
+		t.Fatal("Expected an error registering without the API.")
+	}
+	if code := serveWebhook(router, webhookRequest(t, "webhook-to-card-commentCard.json")); code != http.StatusNotFound {
+		t.Errorf("Expected a 404 after the failed registration. Got %d.", code)
+	}
+}
+
+func TestWebhookRouterPersistsRoutes(t *testing.T) {
+	api := newWebhookAPI()
+	defer api.server.Close()
+	store := NewFileWebhookStore(filepath.Join(t.TempDir(), "webhooks.json"))
+
+	router, err := NewWebhookRouter(api.client(), testWebhookCallbackURL, testWebhookSecret, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := router.Register("57f0752fe7074732b13358df", "cards", ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := router.Register("57f039fbc0f98772398d289d", "boards", ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := router.Register("57f0752fe7074732b13358df", "other", ""); err != nil {
+		t.Fatal(err)
+	}
+	if api.created != 2 {
+		t.Errorf("Expected re-registering to reuse the webhook. Created %d.", api.created)
+	}
+
+	restarted, err := NewWebhookRouter(api.client(), testWebhookCallbackURL, testWebhookSecret, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	routes := restarted.Routes()
+	expected := []WebhookRoute{
+		{WebhookID: "webhook2", IDModel: "57f039fbc0f98772398d289d", Handler: "boards"},
+		{WebhookID: "webhook1", IDModel: "57f0752fe7074732b13358df", Handler: "other"},
+	}
+	if len(routes) != len(expected) || routes[0] != expected[0] || routes[1] != expected[1] {
+		t.Errorf("Expected %v. Got %v.", expected, routes)
+	}
+
+	if code := serveWebhook(restarted, webhookRequest(t, "webhook-to-card-commentCard.json")); code != http.StatusInternalServerError {
+		t.Errorf("Expected a 500 until the handler is set. Got %d.", code)
+	}
+	restarted.Handle("other", func(e *WebhookEvent) error { return errors.New("try again") })
+	if code := serveWebhook(restarted, webhookRequest(t, "webhook-to-card-commentCard.json")); code != http.StatusInternalServerError {
+		t.Errorf("Expected a 500 when the handler fails. Got %d.", code)
+	}
+	restarted.Handle("other", func(e *WebhookEvent) error { return nil })
+	if code := serveWebhook(restarted, webhookRequest(t, "webhook-to-card-commentCard.json")); code != http.StatusOK {
+		t.Errorf("Expected a 200. Got %d.", code)
+	}
+}
+
+func TestFileWebhookStoreMissingFile(t *testing.T) {
+	store := NewFileWebhookStore(filepath.Join(t.TempDir(), "missing.json"))
+	routes, err := store.LoadWebhookRoutes()
+	if err != nil || len(routes) != 0 {
+		t.Errorf("Expected no routes. Got %v, %v.", routes, err)
+	}
+}
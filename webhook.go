@@ -92,40 +92,38 @@ func (t *Token) GetWebhooks(extraArgs ...Arguments) (webhooks []*Webhook, err er
 }
 
 // GetBoardWebhookRequest takes a http.Request and returns the decoded body as BoardWebhookRequest or an error.
+//
+// Deprecated: use ParseWebhook, which handles webhooks on any kind of model.
 func GetBoardWebhookRequest(r *http.Request) (whr *BoardWebhookRequest, err error) {
-	if r.Method == "HEAD" {
-		return &BoardWebhookRequest{}, nil
-	}
-	decoder := json.NewDecoder(r.Body)
-	err = decoder.Decode(&whr)
-	if err != nil {
-		err = fmt.Errorf("GetBoardWebhookRequest() failed to decode '%s': %w", r.URL, err)
-	}
+	whr = &BoardWebhookRequest{}
+	err = decodeWebhookRequest(r, "GetBoardWebhookRequest", whr)
 	return
 }
 
 // GetListWebhookRequest takes a http.Request and returns the decoded Body as ListWebhookRequest or an error.
+//
+// Deprecated: use ParseWebhook, which handles webhooks on any kind of model.
 func GetListWebhookRequest(r *http.Request) (whr *ListWebhookRequest, err error) {
-	if r.Method == "HEAD" {
-		return &ListWebhookRequest{}, nil
-	}
-	decoder := json.NewDecoder(r.Body)
-	err = decoder.Decode(&whr)
-	if err != nil {
-		err = fmt.Errorf("GetListWebhookRequest() failed to decode '%s': %w", r.URL, err)
-	}
+	whr = &ListWebhookRequest{}
+	err = decodeWebhookRequest(r, "GetListWebhookRequest", whr)
 	return
 }
 
 // GetCardWebhookRequest takes a http.Request and returns the decoded Body as CardWebhookRequest or an error.
+//
+// Deprecated: use ParseWebhook, which handles webhooks on any kind of model.
 func GetCardWebhookRequest(r *http.Request) (whr *CardWebhookRequest, err error) {
-	if r.Method == "HEAD" {
-		return &CardWebhookRequest{}, nil
+	whr = &CardWebhookRequest{}
+	err = decodeWebhookRequest(r, "GetCardWebhookRequest", whr)
+	return
+}
+
+func decodeWebhookRequest(r *http.Request, caller string, whr interface{}) error {
+	if r.Method == http.MethodHead {
+		return nil
 	}
-	decoder := json.NewDecoder(r.Body)
-	err = decoder.Decode(&whr)
-	if err != nil {
-		err = fmt.Errorf("GetCardWebhookRequest() failed to decode '%s': %w", r.URL, err)
+	if err := json.NewDecoder(r.Body).Decode(whr); err != nil {
+		return fmt.Errorf("%s() failed to decode '%s': %w", caller, r.URL, err)
 	}
-	return
+	return nil
 }